@@ -0,0 +1,104 @@
+package bvmgo_reflect
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FieldOptions configures GetFieldStringWith and SetFieldWith functions.
+type FieldOptions struct {
+	// UseAccessors enables accessor methods when structure has no exported field named fieldName:
+	// getters are "GetX()" or "X()" methods (optionally returning an error as last result)
+	// and setters are "SetX(value)" methods (optionally returning an error).
+	UseAccessors bool
+}
+
+// accessorName function returns the field name with an upper case first letter ("name" -> "Name").
+func accessorName(fieldName string) string {
+	firstRune, size := utf8.DecodeRuneInString(fieldName)
+	return string(unicode.ToUpper(firstRune)) + fieldName[size:]
+}
+
+// hasExportedField function returns true if source is a structure (or a pointer to a structure)
+// with an exported field named fieldName. Sources which are not structures return true (no accessor).
+func hasExportedField(source reflect.Value, fieldName string) bool {
+	for source.Kind() == reflect.Ptr && !source.IsNil() {
+		source = source.Elem()
+	}
+	if source.Kind() != reflect.Struct {
+		return true
+	}
+	field, found := source.Type().FieldByName(fieldName)
+	return found && field.IsExported()
+}
+
+// callGetter function calls the "GetX" or "X" getter of source.
+//
+// found is false if source has no getter for fieldName.
+func callGetter(source any, fieldName string) (value reflect.Value, found bool, err error) {
+	cleanFieldName := strings.TrimSpace(fieldName)
+	if !hasExportedField(reflect.ValueOf(source), cleanFieldName) && len(cleanFieldName) > 0 {
+		for _, methodName := range []string{"Get" + accessorName(cleanFieldName), accessorName(cleanFieldName)} {
+			method, targetType, methodErr := findMethod(source, methodName)
+			if methodErr != nil {
+				continue
+			}
+			methodType := method.Type()
+			hasError := methodType.NumOut() == 2 && methodType.Out(1) == errorType
+			if methodType.NumIn() != 0 || (methodType.NumOut() != 1 && !hasError) {
+				continue
+			}
+			found = true
+			results := method.Call(nil)
+			if hasError && !results[1].IsNil() {
+				err = fmt.Errorf("[%s.%s] getter returns an error: %w",
+					typeName(targetType), methodName, results[1].Interface().(error))
+				return
+			}
+			value = results[0]
+			return
+		}
+	}
+	return
+}
+
+// callSetter function calls the "SetX" setter of target with value.
+//
+// found is false if target has no setter for fieldName, or if target is not a not nil pointer
+// (a setter called on a copy would lose the value).
+func callSetter[T any](target any, fieldName string, value T) (found bool, err error) {
+	cleanFieldName := strings.TrimSpace(fieldName)
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() {
+		return
+	}
+	if hasExportedField(targetValue, cleanFieldName) || len(cleanFieldName) == 0 {
+		return
+	}
+	methodName := "Set" + accessorName(cleanFieldName)
+	method, targetType, methodErr := findMethod(target, methodName)
+	if methodErr != nil {
+		return
+	}
+	methodType := method.Type()
+	hasError := methodType.NumOut() == 1 && methodType.Out(0) == errorType
+	if methodType.NumIn() != 1 || (methodType.NumOut() != 0 && !hasError) {
+		return
+	}
+	found = true
+	argument := reflect.New(methodType.In(0)).Elem()
+	if err = setValueToReflectValue(argument, value); err != nil {
+		err = fmt.Errorf("[%s.%s] setter cannot be called with current value: %w",
+			typeName(targetType), methodName, err)
+		return
+	}
+	results := method.Call([]reflect.Value{argument})
+	if hasError && !results[0].IsNil() {
+		err = fmt.Errorf("[%s.%s] setter returns an error: %w",
+			typeName(targetType), methodName, results[0].Interface().(error))
+	}
+	return
+}
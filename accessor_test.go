@@ -0,0 +1,157 @@
+package bvmgo_reflect
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testAccessorStruct struct {
+	Public string
+	name   string
+	title  string
+	code   string
+}
+
+func (test *testAccessorStruct) GetName() string {
+	return test.name
+}
+
+func (test *testAccessorStruct) SetName(name string) {
+	test.name = name
+}
+
+func (test testAccessorStruct) Title() string {
+	return test.title
+}
+
+func (test *testAccessorStruct) SetTitle(title *string) error {
+	if len(*title) == 0 {
+		return errors.New("title is required")
+	}
+	test.title = *title
+	return nil
+}
+
+func (test *testAccessorStruct) Code() (string, error) {
+	if len(test.code) == 0 {
+		return "", errors.New("code is not defined")
+	}
+	return test.code, nil
+}
+
+func TestGetFieldStringWith_accessors(t *testing.T) {
+	testStruct := testAccessorStruct{Public: "public", name: "my name", title: "my title"}
+	options := FieldOptions{UseAccessors: true}
+	tests := []struct {
+		fieldName string
+		want      string
+	}{
+		{fieldName: "Public", want: "public"},
+		{fieldName: "Name", want: "my name"},
+		{fieldName: "name", want: "my name"},
+		{fieldName: "Title", want: "my title"},
+	}
+	for _, tt := range tests {
+		findValue, err := GetFieldStringWith(&testStruct, tt.fieldName, options)
+		if err != nil {
+			t.Errorf("GetFieldStringWith(%s) returns \"%v\" error, want no error", tt.fieldName, err)
+			continue
+		}
+		if findValue != tt.want {
+			t.Errorf("GetFieldStringWith(%s) = [%v], want [%v]", tt.fieldName, findValue, tt.want)
+		}
+	}
+	// Pointer receiver getter called on a value
+	findValue, err := GetFieldStringWith(testStruct, "Name", options)
+	if err != nil || findValue != "my name" {
+		t.Errorf("GetFieldStringWith(...) = [%v] (error %v), want [%v]", findValue, err, "my name")
+	}
+}
+
+func TestGetFieldStringWith_getterError(t *testing.T) {
+	_, err := GetFieldStringWith(&testAccessorStruct{}, "Code", FieldOptions{UseAccessors: true})
+	if err == nil {
+		t.Errorf("GetFieldStringWith(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "[*bvmgo_reflect.testAccessorStruct.Code] getter returns an error") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "getter returns an error")
+	}
+}
+
+func TestGetFieldStringWith_noAccessors(t *testing.T) {
+	_, err := GetFieldStringWith(&testAccessorStruct{}, "Name", FieldOptions{})
+	if err == nil {
+		t.Errorf("GetFieldStringWith(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "field is not found") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "field is not found")
+	}
+}
+
+func TestSetFieldWith_accessors(t *testing.T) {
+	testStruct := testAccessorStruct{}
+	options := FieldOptions{UseAccessors: true}
+	if err := SetFieldWith(&testStruct, "Name", "new name", options); err != nil {
+		t.Errorf("SetFieldWith() error = %v, want no Error", err)
+	}
+	if err := SetFieldWith(&testStruct, "title", "new title", options); err != nil {
+		t.Errorf("SetFieldWith() error = %v, want no Error", err)
+	}
+	if err := SetFieldWith(&testStruct, "Public", "new public", options); err != nil {
+		t.Errorf("SetFieldWith() error = %v, want no Error", err)
+	}
+	expected := testAccessorStruct{Public: "new public", name: "new name", title: "new title"}
+	if testStruct != expected {
+		t.Errorf("SetFieldWith() testStruct = %v, want = %v", testStruct, expected)
+	}
+}
+
+func TestSetFieldWith_errors(t *testing.T) {
+	testStruct := testAccessorStruct{}
+	tests := []struct {
+		name      string
+		fieldName string
+		value     any
+		options   FieldOptions
+		want      string
+	}{
+		{name: "setter error", fieldName: "Title", value: "", options: FieldOptions{UseAccessors: true},
+			want: "[*bvmgo_reflect.testAccessorStruct.SetTitle] setter returns an error: title is required"},
+		{name: "bad value", fieldName: "Name", value: 12, options: FieldOptions{UseAccessors: true},
+			want: "setter cannot be called with current value"},
+		{name: "no setter", fieldName: "Code", value: "x", options: FieldOptions{UseAccessors: true},
+			want: "field is not found"},
+		{name: "accessors disabled", fieldName: "name", value: "x", options: FieldOptions{},
+			want: "field is private"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetFieldWith(&testStruct, tt.fieldName, tt.value, tt.options)
+			if err == nil {
+				t.Errorf("SetFieldWith(...) returns nil (no error), want an error")
+				return
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSetFieldWith_notPointer(t *testing.T) {
+	testStruct := testAccessorStruct{}
+	err := SetFieldWith(testStruct, "Name", "new name", FieldOptions{UseAccessors: true})
+	if err == nil {
+		t.Errorf("SetFieldWith(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "a pointer to a structure is required") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "a pointer to a structure is required")
+	}
+	if testStruct.name != "" {
+		t.Errorf("SetFieldWith() testStruct.name = %q, want empty", testStruct.name)
+	}
+}
@@ -0,0 +1,80 @@
+package bvmgo_reflect
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"reflect"
+	"strings"
+)
+
+// StructBuilder assembles new structure types at runtime (see reflect.StructOf).
+//
+// Errors of AddField calls are reported by Build function.
+type StructBuilder struct {
+	fields []reflect.StructField
+	names  map[string]bool
+	errs   []error
+}
+
+// DynamicStruct is a structure type built by a StructBuilder.
+type DynamicStruct struct {
+	// Type is the structure type.
+	Type reflect.Type
+}
+
+// NewStructBuilder function returns a new builder without field.
+func NewStructBuilder() *StructBuilder {
+	return &StructBuilder{names: make(map[string]bool)}
+}
+
+// AddField function adds a field to the structure, with a tag (`json:"name"` format, may be empty).
+//
+// The field name must be an exported Go identifier, unique in the structure.
+func (builder *StructBuilder) AddField(name string, fieldType reflect.Type, tag string) *StructBuilder {
+	cleanName := strings.TrimSpace(name)
+	switch {
+	case !token.IsIdentifier(cleanName) || !token.IsExported(cleanName):
+		builder.errs = append(builder.errs, fmt.Errorf("[%s] field name is not an exported identifier", cleanName))
+	case fieldType == nil:
+		builder.errs = append(builder.errs, fmt.Errorf("[%s] field type is nil", cleanName))
+	case builder.names[cleanName]:
+		builder.errs = append(builder.errs, fmt.Errorf("[%s] field is already defined", cleanName))
+	default:
+		builder.names[cleanName] = true
+		builder.fields = append(builder.fields, reflect.StructField{
+			Name: cleanName,
+			Type: fieldType,
+			Tag:  reflect.StructTag(tag),
+		})
+	}
+	return builder
+}
+
+// Build function returns the built structure type.
+//
+// Build function returns an error if a field definition is invalid.
+func (builder *StructBuilder) Build() (dynamicStruct *DynamicStruct, err error) {
+	if len(builder.errs) > 0 {
+		return nil, fmt.Errorf("structure cannot be built: %w", errors.Join(builder.errs...))
+	}
+	defer func() {
+		// reflect.StructOf panics on invalid definitions
+		if recovered := recover(); recovered != nil {
+			dynamicStruct = nil
+			err = fmt.Errorf("structure cannot be built: %v", recovered)
+		}
+	}()
+	fields := append([]reflect.StructField(nil), builder.fields...)
+	return &DynamicStruct{Type: reflect.StructOf(fields)}, nil
+}
+
+// New function returns a pointer to a new zero structure, usable with SetField and GetFieldString functions.
+func (dynamicStruct *DynamicStruct) New() any {
+	return reflect.New(dynamicStruct.Type).Interface()
+}
+
+// NewSlice function returns a pointer to a new empty slice of structures, usable with ScanRows function.
+func (dynamicStruct *DynamicStruct) NewSlice() any {
+	return reflect.New(reflect.SliceOf(dynamicStruct.Type)).Interface()
+}
@@ -0,0 +1,89 @@
+package bvmgo_reflect
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStructBuilder(t *testing.T) {
+	dynamicStruct, err := NewStructBuilder().
+		AddField("Name", reflect.TypeOf(""), `json:"name"`).
+		AddField("Age", reflect.TypeOf(0), `json:"age,omitempty"`).
+		Build()
+	if err != nil {
+		t.Errorf("Build() returns \"%v\" error, want no error", err)
+		return
+	}
+	if dynamicStruct.Type.NumField() != 2 {
+		t.Errorf("dynamicStruct.Type.NumField() = %v, want %v", dynamicStruct.Type.NumField(), 2)
+	}
+	instance := dynamicStruct.New()
+	if err = SetField(instance, "Name", "alice"); err != nil {
+		t.Errorf("SetField(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if err = SetField(instance, "Age", 30); err != nil {
+		t.Errorf("SetField(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	name, err := GetFieldString(instance, "Name")
+	if err != nil || name != "alice" {
+		t.Errorf("GetFieldString(...) = [%v, %v], want [alice, nil]", name, err)
+	}
+	data, _ := json.Marshal(instance)
+	if string(data) != `{"name":"alice","age":30}` {
+		t.Errorf("json.Marshal(...) = %s, want %s", data, `{"name":"alice","age":30}`)
+	}
+	slice := dynamicStruct.NewSlice()
+	if reflect.TypeOf(slice) != reflect.PointerTo(reflect.SliceOf(dynamicStruct.Type)) {
+		t.Errorf("NewSlice() type = %v, want pointer to slice", TypeName(slice))
+	}
+}
+
+func TestStructBuilder_scanRows(t *testing.T) {
+	dynamicStruct, err := NewStructBuilder().
+		AddField("ID", reflect.TypeOf(int64(0)), `db:"id"`).
+		AddField("UserName", reflect.TypeOf(""), "").
+		Build()
+	if err != nil {
+		t.Errorf("Build() returns \"%v\" error, want no error", err)
+		return
+	}
+	db, rows := testSQLQuery(t, "users")
+	defer db.Close()
+	defer rows.Close()
+	slice := dynamicStruct.NewSlice()
+	if err = ScanRows(rows, slice); err != nil {
+		t.Errorf("ScanRows(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	sliceValue := reflect.ValueOf(slice).Elem()
+	if sliceValue.Len() != 2 || sliceValue.Index(1).Field(1).String() != "bob" {
+		t.Errorf("ScanRows(...) = %v, want 2 rows", sliceValue.Interface())
+	}
+}
+
+func TestStructBuilder_errors(t *testing.T) {
+	_, err := NewStructBuilder().
+		AddField("name", reflect.TypeOf(""), "").
+		AddField("Value", nil, "").
+		AddField("Twice", reflect.TypeOf(""), "").
+		AddField("Twice", reflect.TypeOf(0), "").
+		Build()
+	if err == nil {
+		t.Errorf("Build() returns nil (no error), want an error")
+		return
+	}
+	tests := []string{
+		"[name] field name is not an exported identifier",
+		"[Value] field type is nil",
+		"[Twice] field is already defined",
+	}
+	for _, want := range tests {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package bvmgo_reflect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// contextType is the reflect.Type of context.Context interface.
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// Bus dispatches published events to the handlers subscribed to their type. A Bus is safe for concurrent use.
+type Bus struct {
+	mutex         sync.RWMutex
+	subscriptions []*busSubscription
+}
+
+// busSubscription is a handler subscribed to an event type.
+type busSubscription struct {
+	eventType   reflect.Type
+	handler     reflect.Value
+	withContext bool
+}
+
+// NewBus function returns a new bus without subscription.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe function subscribes a handler to the events of its event type, and returns a function to unsubscribe.
+//
+// Handler signature is func(EventType), func(EventType) error, func(context.Context, EventType)
+// or func(context.Context, EventType) error. If EventType is an interface, the handler receives all events
+// implementing it.
+//
+// Subscribe function returns an error if handler is not a function with a supported signature.
+func (bus *Bus) Subscribe(handler any) (unsubscribe func(), err error) {
+	handlerValue := reflect.ValueOf(handler)
+	if !handlerValue.IsValid() || handlerValue.Kind() != reflect.Func || handlerValue.IsNil() {
+		err = fmt.Errorf("unsupported type [%s], a handler function is required to subscribe", TypeName(handler))
+		return
+	}
+	handlerType := handlerValue.Type()
+	withContext := handlerType.NumIn() == 2 && handlerType.In(0) == contextType
+	validResults := handlerType.NumOut() == 0 || (handlerType.NumOut() == 1 && handlerType.Out(0) == errorType)
+	if (handlerType.NumIn() != 1 && !withContext) || handlerType.IsVariadic() || !validResults {
+		err = fmt.Errorf("unsupported handler type [%s], func(EventType) or func(context.Context, EventType) error is required",
+			typeName(handlerType))
+		return
+	}
+	subscription := &busSubscription{
+		eventType:   handlerType.In(handlerType.NumIn() - 1),
+		handler:     handlerValue,
+		withContext: withContext,
+	}
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.subscriptions = append(bus.subscriptions, subscription)
+	unsubscribe = func() {
+		bus.mutex.Lock()
+		defer bus.mutex.Unlock()
+		for index, existing := range bus.subscriptions {
+			if existing == subscription {
+				bus.subscriptions = append(bus.subscriptions[:index:index], bus.subscriptions[index+1:]...)
+				return
+			}
+		}
+	}
+	return
+}
+
+// Publish function calls synchronously, in subscription order, the handlers subscribed to the event type
+// (or to an interface implemented by the event type).
+//
+// Publish function returns the errors returned by handlers (or their panics), joined with errors.Join.
+func (bus *Bus) Publish(ctx context.Context, event any) error {
+	var errs []error
+	for _, subscription := range bus.matchingSubscriptions(event) {
+		if err := subscription.call(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// PublishAsync function calls concurrently the handlers subscribed to the event type
+// (or to an interface implemented by the event type).
+//
+// The returned channel receives the errors returned by handlers (or their panics), joined with errors.Join,
+// when all handlers are done.
+func (bus *Bus) PublishAsync(ctx context.Context, event any) <-chan error {
+	subscriptions := bus.matchingSubscriptions(event)
+	done := make(chan error, 1)
+	errs := make([]error, len(subscriptions))
+	var waitGroup sync.WaitGroup
+	for index, subscription := range subscriptions {
+		waitGroup.Add(1)
+		go func(index int, subscription *busSubscription) {
+			defer waitGroup.Done()
+			errs[index] = subscription.call(ctx, event)
+		}(index, subscription)
+	}
+	go func() {
+		waitGroup.Wait()
+		done <- errors.Join(errs...)
+		close(done)
+	}()
+	return done
+}
+
+// matchingSubscriptions function returns the subscriptions matching the event type.
+func (bus *Bus) matchingSubscriptions(event any) []*busSubscription {
+	eventType := reflect.TypeOf(event)
+	if eventType == nil {
+		return nil
+	}
+	bus.mutex.RLock()
+	defer bus.mutex.RUnlock()
+	var subscriptions []*busSubscription
+	for _, subscription := range bus.subscriptions {
+		if eventType == subscription.eventType ||
+			(subscription.eventType.Kind() == reflect.Interface && eventType.Implements(subscription.eventType)) {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return subscriptions
+}
+
+// call function calls the subscription handler with the event, a handler panic is returned as an error.
+func (subscription *busSubscription) call(ctx context.Context, event any) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("[%s] event handler [%s] panics: %v",
+				TypeName(event), typeName(subscription.handler.Type()), recovered)
+		}
+	}()
+	argumentValues := []reflect.Value{reflect.ValueOf(event)}
+	if subscription.withContext {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		argumentValues = []reflect.Value{reflect.ValueOf(&ctx).Elem(), reflect.ValueOf(event)}
+	}
+	results := subscription.handler.Call(argumentValues)
+	if len(results) == 1 && !results[0].IsNil() {
+		err = fmt.Errorf("[%s] event handler [%s] returns an error: %w",
+			TypeName(event), typeName(subscription.handler.Type()), results[0].Interface().(error))
+	}
+	return
+}
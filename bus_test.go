@@ -0,0 +1,127 @@
+package bvmgo_reflect
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testBusEvent interface {
+	EventName() string
+}
+
+type testBusCreated struct {
+	ID int
+}
+
+func (event testBusCreated) EventName() string { return "created" }
+
+type testBusDeleted struct {
+	ID int
+}
+
+func (event *testBusDeleted) EventName() string { return "deleted" }
+
+func TestBus_publish(t *testing.T) {
+	bus := NewBus()
+	var received []string
+	handlers := []any{
+		func(event testBusCreated) { received = append(received, "created") },
+		func(ctx context.Context, event testBusCreated) error {
+			received = append(received, "created with context")
+			return nil
+		},
+		func(event testBusEvent) error {
+			received = append(received, "any "+event.EventName())
+			return nil
+		},
+		func(event *testBusDeleted) { received = append(received, "deleted") },
+	}
+	for _, handler := range handlers {
+		if _, err := bus.Subscribe(handler); err != nil {
+			t.Errorf("Subscribe(...) returns \"%v\" error, want no error", err)
+			return
+		}
+	}
+	if err := bus.Publish(context.Background(), testBusCreated{ID: 1}); err != nil {
+		t.Errorf("Publish(...) returns \"%v\" error, want no error", err)
+	}
+	if err := bus.Publish(context.Background(), &testBusDeleted{ID: 1}); err != nil {
+		t.Errorf("Publish(...) returns \"%v\" error, want no error", err)
+	}
+	_ = bus.Publish(context.Background(), "ignored")
+	expected := []string{"created", "created with context", "any created", "any deleted", "deleted"}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("received = %v, want %v", received, expected)
+	}
+}
+
+func TestBus_errors(t *testing.T) {
+	bus := NewBus()
+	_, _ = bus.Subscribe(func(event testBusCreated) error { return errors.New("first failure") })
+	_, _ = bus.Subscribe(func(event testBusCreated) { panic("second failure") })
+	err := bus.Publish(context.TODO(), testBusCreated{})
+	if err == nil {
+		t.Errorf("Publish(...) returns nil (no error), want an error")
+		return
+	}
+	tests := []string{
+		"[bvmgo_reflect.testBusCreated] event handler [func(bvmgo_reflect.testBusCreated) error] returns an error: first failure",
+		"[bvmgo_reflect.testBusCreated] event handler [func(bvmgo_reflect.testBusCreated)] panics: second failure",
+	}
+	for _, want := range tests {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), want)
+		}
+	}
+}
+
+func TestBus_publishAsync(t *testing.T) {
+	bus := NewBus()
+	var mutex sync.Mutex
+	count := 0
+	for index := 0; index < 5; index++ {
+		_, _ = bus.Subscribe(func(ctx context.Context, event testBusEvent) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			count++
+			return nil
+		})
+	}
+	if err := <-bus.PublishAsync(context.Background(), testBusCreated{}); err != nil {
+		t.Errorf("PublishAsync(...) returns \"%v\" error, want no error", err)
+	}
+	if count != 5 {
+		t.Errorf("count = %v, want %v", count, 5)
+	}
+}
+
+func TestBus_unsubscribe(t *testing.T) {
+	bus := NewBus()
+	count := 0
+	unsubscribe, _ := bus.Subscribe(func(event testBusCreated) { count++ })
+	_ = bus.Publish(context.Background(), testBusCreated{})
+	unsubscribe()
+	_ = bus.Publish(context.Background(), testBusCreated{})
+	if count != 1 {
+		t.Errorf("count = %v, want %v", count, 1)
+	}
+}
+
+func TestBus_subscribeInvalidHandler(t *testing.T) {
+	bus := NewBus()
+	tests := []any{"not a function", func(a int, b int) {}, func(event int) int { return 0 }, func(events ...int) {}}
+	for _, handler := range tests {
+		_, err := bus.Subscribe(handler)
+		if err == nil {
+			t.Errorf("Subscribe(%s) returns nil (no error), want an error", TypeName(handler))
+			continue
+		}
+		if !strings.Contains(err.Error(), "unsupported") {
+			t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "unsupported")
+		}
+	}
+}
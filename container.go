@@ -0,0 +1,313 @@
+package bvmgo_reflect
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Scope defines the lifetime of instances created by a Container provider.
+type Scope int
+
+const (
+	// Singleton providers create one instance, shared by all dependents.
+	Singleton Scope = iota
+	// Transient providers create a new instance for each dependent.
+	Transient
+)
+
+// ProvideOptions configures Container.ProvideWith function.
+type ProvideOptions struct {
+	// Name distinguishes several providers of the same type (empty for the default provider).
+	Name string
+	// Scope is the lifetime of created instances.
+	Scope Scope
+}
+
+// Container is a dependency injection container: constructors are registered as providers of their result type,
+// and their parameters are resolved from other providers. A Container is safe for concurrent use.
+//
+// Constructor parameters are resolved from default (unnamed) providers only: named providers are injected
+// with Populate function or resolved with Resolve function.
+// Constructors are called without locking the container: they may resolve other dependencies,
+// but not the dependency they construct.
+type Container struct {
+	mutex     sync.Mutex
+	providers map[dependencyKey]*provider
+}
+
+// dependencyKey identifies a provider: a type and a name.
+type dependencyKey struct {
+	dependencyType reflect.Type
+	name           string
+}
+
+// provider creates instances of a type with a constructor.
+type provider struct {
+	constructor reflect.Value
+	scope       Scope
+	// mutex serializes the creation of the singleton instance.
+	mutex    sync.Mutex
+	instance reflect.Value
+}
+
+// NewContainer function returns a new empty container.
+func NewContainer() *Container {
+	return &Container{providers: make(map[dependencyKey]*provider)}
+}
+
+// String function returns the name of the dependency ("Type" or "Type[name]").
+func (key dependencyKey) String() string {
+	if len(key.name) == 0 {
+		return typeName(key.dependencyType)
+	}
+	return typeName(key.dependencyType) + "[" + key.name + "]"
+}
+
+// Provide function registers a singleton constructor: a function returning an instance
+// (and optionally an error), whose parameters are dependencies.
+//
+// See ProvideWith function.
+func (container *Container) Provide(constructor any) error {
+	return container.ProvideWith(constructor, ProvideOptions{})
+}
+
+// ProvideWith function registers a constructor with options.
+//
+// ProvideWith function returns an error if:
+//   - constructor is not a function,
+//   - constructor is variadic,
+//   - constructor does not return one instance (and optionally an error),
+//   - a provider is already registered for the constructor result type and name.
+func (container *Container) ProvideWith(constructor any, options ProvideOptions) error {
+	constructorValue := reflect.ValueOf(constructor)
+	if !constructorValue.IsValid() || constructorValue.Kind() != reflect.Func || constructorValue.IsNil() {
+		return fmt.Errorf("unsupported type [%s], a constructor function is required to provide a dependency",
+			TypeName(constructor))
+	}
+	constructorType := constructorValue.Type()
+	if constructorType.IsVariadic() {
+		return fmt.Errorf("[%s] constructor is variadic, variadic constructors are not supported",
+			typeName(constructorType))
+	}
+	resultCount := constructorType.NumOut()
+	if resultCount == 2 && constructorType.Out(1) == errorType {
+		resultCount = 1
+	}
+	if resultCount != 1 || constructorType.Out(0) == errorType {
+		return fmt.Errorf("[%s] constructor must return an instance and optionally an error",
+			typeName(constructorType))
+	}
+	key := dependencyKey{dependencyType: constructorType.Out(0), name: strings.TrimSpace(options.Name)}
+	container.mutex.Lock()
+	defer container.mutex.Unlock()
+	if _, exists := container.providers[key]; exists {
+		return fmt.Errorf("[%s] dependency is already provided", key)
+	}
+	container.providers[key] = &provider{constructor: constructorValue, scope: options.Scope}
+	return nil
+}
+
+// Invoke function calls function with its parameters resolved from default providers and returns its results.
+//
+// If the last result of function is an error, it is removed from results and returned as Invoke error.
+//
+// Invoke function returns an error if:
+//   - function is not a function,
+//   - function is variadic,
+//   - a dependency cannot be resolved (see Resolve function),
+//   - function returns a not nil error.
+func (container *Container) Invoke(function any) ([]any, error) {
+	functionValue := reflect.ValueOf(function)
+	if !functionValue.IsValid() || functionValue.Kind() != reflect.Func || functionValue.IsNil() {
+		return nil, fmt.Errorf("unsupported type [%s], a function is required to invoke", TypeName(function))
+	}
+	if functionValue.Type().IsVariadic() {
+		return nil, fmt.Errorf("[%s] function is variadic, variadic functions are not supported",
+			typeName(functionValue.Type()))
+	}
+	argumentValues, err := container.resolveParameters(functionValue.Type())
+	if err != nil {
+		return nil, err
+	}
+	return unpackResults(functionValue.Type(), functionValue.Call(argumentValues))
+}
+
+// Populate function assigns resolved dependencies to the fields of a structure tagged `inject:""`
+// (or `inject:"name"` for a named provider), with SetField function.
+//
+// Populate function returns an error if:
+//   - targetStructurePointer is not a pointer to a structure,
+//   - a dependency cannot be resolved (see Resolve function),
+//   - a field cannot be set (private field...).
+func (container *Container) Populate(targetStructurePointer any) error {
+	ptrTarget := reflect.ValueOf(targetStructurePointer)
+	// Check is not null
+	if !ptrTarget.IsValid() || (ptrTarget.Kind() == reflect.Ptr && ptrTarget.IsNil()) {
+		return fmt.Errorf("a not nil pointer is required to populate dependencies")
+	}
+	// Check is a pointer to a structure
+	if ptrTarget.Kind() != reflect.Ptr || ptrTarget.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported type [%s], a pointer to a structure is required to populate dependencies",
+			typeName(ptrTarget.Type()))
+	}
+	structType := ptrTarget.Elem().Type()
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		name, tagged := field.Tag.Lookup("inject")
+		if !tagged {
+			continue
+		}
+		dependency, err := container.resolve(dependencyKey{dependencyType: field.Type, name: strings.TrimSpace(name)})
+		if err != nil {
+			return fmt.Errorf("[%s.%s] field cannot be populated: %w", typeName(structType), field.Name, err)
+		}
+		if err = SetField(targetStructurePointer, field.Name, dependency.Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Resolve function returns the instance of dependencyType from the provider registered with name.
+//
+// Resolve function returns an error if:
+//   - no provider is registered for the dependency (or one of its dependencies),
+//   - dependencies contain a cycle (the error contains the dependency chain),
+//   - a constructor returns an error.
+func (container *Container) Resolve(dependencyType reflect.Type, name string) (any, error) {
+	if dependencyType == nil {
+		return nil, fmt.Errorf("a not nil type is required to resolve a dependency")
+	}
+	dependency, err := container.resolve(dependencyKey{dependencyType: dependencyType, name: strings.TrimSpace(name)})
+	if err != nil {
+		return nil, err
+	}
+	return dependency.Interface(), nil
+}
+
+// Resolve function returns the instance of T type from the default provider of container.
+//
+// See Container.Resolve function.
+func Resolve[T any](container *Container) (T, error) {
+	return ResolveNamed[T](container, "")
+}
+
+// ResolveNamed function returns the instance of T type from the provider of container registered with name.
+//
+// See Container.Resolve function.
+func ResolveNamed[T any](container *Container, name string) (T, error) {
+	var instance T
+	dependency, err := container.Resolve(reflect.TypeOf((*T)(nil)).Elem(), name)
+	if err != nil {
+		return instance, err
+	}
+	if dependency != nil {
+		instance = dependency.(T)
+	}
+	return instance, nil
+}
+
+// provider function returns the provider registered for key (nil if not registered).
+func (container *Container) provider(key dependencyKey) *provider {
+	container.mutex.Lock()
+	defer container.mutex.Unlock()
+	return container.providers[key]
+}
+
+// resolve function returns the instance of a dependency.
+//
+// Dependencies are checked before calling any constructor, so constructors of a dependency cycle are never called
+// (and concurrent resolutions cannot wait for each other's singletons).
+func (container *Container) resolve(key dependencyKey) (reflect.Value, error) {
+	if err := container.checkDependencies(key, nil, make(map[dependencyKey]bool)); err != nil {
+		return reflect.Value{}, err
+	}
+	return container.construct(key)
+}
+
+// checkDependencies function returns an error if a dependency (or one of its dependencies) is not provided
+// or if dependencies contain a cycle.
+//
+// chain contains the dependencies being checked, checked contains the dependencies already checked.
+func (container *Container) checkDependencies(key dependencyKey, chain []dependencyKey, checked map[dependencyKey]bool) error {
+	for index, resolving := range chain {
+		if resolving == key {
+			cycle := append(append([]dependencyKey{}, chain[index:]...), key)
+			return fmt.Errorf("dependency cycle: %s", formatDependencyChain(cycle))
+		}
+	}
+	if checked[key] {
+		return nil
+	}
+	dependencyProvider := container.provider(key)
+	if dependencyProvider == nil {
+		if len(chain) == 0 {
+			return fmt.Errorf("[%s] dependency is not provided", key)
+		}
+		return fmt.Errorf("[%s] dependency is not provided (required by %s)", key, formatDependencyChain(chain))
+	}
+	chain = append(chain, key)
+	constructorType := dependencyProvider.constructor.Type()
+	for index := 0; index < constructorType.NumIn(); index++ {
+		parameterKey := dependencyKey{dependencyType: constructorType.In(index)}
+		if err := container.checkDependencies(parameterKey, chain, checked); err != nil {
+			return err
+		}
+	}
+	checked[key] = true
+	return nil
+}
+
+// construct function returns the instance of a checked dependency, calling its constructor if needed.
+func (container *Container) construct(key dependencyKey) (reflect.Value, error) {
+	dependencyProvider := container.provider(key)
+	if dependencyProvider.scope == Singleton {
+		dependencyProvider.mutex.Lock()
+		defer dependencyProvider.mutex.Unlock()
+		if dependencyProvider.instance.IsValid() {
+			return dependencyProvider.instance, nil
+		}
+	}
+	constructorType := dependencyProvider.constructor.Type()
+	argumentValues := make([]reflect.Value, constructorType.NumIn())
+	for index := range argumentValues {
+		argumentValue, err := container.construct(dependencyKey{dependencyType: constructorType.In(index)})
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		argumentValues[index] = argumentValue
+	}
+	resultValues := dependencyProvider.constructor.Call(argumentValues)
+	if len(resultValues) == 2 && !resultValues[1].IsNil() {
+		return reflect.Value{}, fmt.Errorf("[%s] dependency constructor returns an error: %w",
+			key, resultValues[1].Interface().(error))
+	}
+	if dependencyProvider.scope == Singleton {
+		dependencyProvider.instance = resultValues[0]
+	}
+	return resultValues[0], nil
+}
+
+// resolveParameters function returns the parameters of a (not variadic) function, resolved from default providers.
+func (container *Container) resolveParameters(functionType reflect.Type) ([]reflect.Value, error) {
+	argumentValues := make([]reflect.Value, functionType.NumIn())
+	for index := range argumentValues {
+		argumentValue, err := container.resolve(dependencyKey{dependencyType: functionType.In(index)})
+		if err != nil {
+			return nil, err
+		}
+		argumentValues[index] = argumentValue
+	}
+	return argumentValues, nil
+}
+
+// formatDependencyChain function returns a dependency chain as text ("[A] -> [B] -> [A]").
+func formatDependencyChain(chain []dependencyKey) string {
+	names := make([]string, len(chain))
+	for index, key := range chain {
+		names[index] = "[" + key.String() + "]"
+	}
+	return strings.Join(names, " -> ")
+}
@@ -0,0 +1,173 @@
+package bvmgo_reflect
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testContainerConfig struct {
+	URL string
+}
+
+type testContainerDatabase struct {
+	Config *testContainerConfig
+}
+
+type testContainerService struct {
+	Database    *testContainerDatabase
+	Primary     *testContainerConfig `inject:"primary"`
+	Default     *testContainerConfig `inject:""`
+	NotInjected string
+}
+
+type testContainerCycleA struct{}
+
+type testContainerCycleB struct{}
+
+func testContainer(t *testing.T) *Container {
+	container := NewContainer()
+	providers := []error{
+		container.Provide(func() *testContainerConfig { return &testContainerConfig{URL: "default"} }),
+		container.ProvideWith(func() *testContainerConfig { return &testContainerConfig{URL: "primary"} },
+			ProvideOptions{Name: "primary"}),
+		container.ProvideWith(func(config *testContainerConfig) (*testContainerDatabase, error) {
+			return &testContainerDatabase{Config: config}, nil
+		}, ProvideOptions{Scope: Transient}),
+	}
+	for _, err := range providers {
+		if err != nil {
+			t.Fatalf("Provide(...) returns \"%v\" error, want no error", err)
+		}
+	}
+	return container
+}
+
+func TestContainer_invoke(t *testing.T) {
+	container := testContainer(t)
+	results, err := container.Invoke(func(database *testContainerDatabase, config *testContainerConfig) (string, error) {
+		if database.Config != config {
+			return "", errors.New("singleton config is not shared")
+		}
+		return database.Config.URL, nil
+	})
+	if err != nil {
+		t.Errorf("Invoke(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if len(results) != 1 || results[0] != "default" {
+		t.Errorf("Invoke(...) = %v, want [default]", results)
+	}
+}
+
+func TestContainer_scopes(t *testing.T) {
+	container := testContainer(t)
+	config1, _ := Resolve[*testContainerConfig](container)
+	config2, _ := Resolve[*testContainerConfig](container)
+	if config1 != config2 {
+		t.Errorf("Resolve() returns different singleton instances, want the same instance")
+	}
+	database1, _ := Resolve[*testContainerDatabase](container)
+	database2, _ := Resolve[*testContainerDatabase](container)
+	if database1 == database2 {
+		t.Errorf("Resolve() returns the same transient instance, want different instances")
+	}
+	primary, err := ResolveNamed[*testContainerConfig](container, "primary")
+	if err != nil || primary.URL != "primary" {
+		t.Errorf("ResolveNamed() = [%v, %v], want primary config", primary, err)
+	}
+}
+
+func TestContainer_populate(t *testing.T) {
+	container := testContainer(t)
+	service := testContainerService{}
+	if err := container.Populate(&service); err != nil {
+		t.Errorf("Populate(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if service.Primary == nil || service.Primary.URL != "primary" || service.Default == nil ||
+		service.Default.URL != "default" || service.Database != nil {
+		t.Errorf("Populate(...) = %+v, want primary and default configs only", service)
+	}
+}
+
+func TestContainer_errors(t *testing.T) {
+	container := NewContainer()
+	_ = container.Provide(func(_ *testContainerCycleB) *testContainerCycleA { return nil })
+	_ = container.Provide(func(_ *testContainerCycleA) *testContainerCycleB { return nil })
+	_ = container.Provide(func(_ *testContainerConfig) *testContainerDatabase { return nil })
+	_, cycleErr := Resolve[*testContainerCycleA](container)
+	_, missingErr := Resolve[*testContainerDatabase](container)
+	_, namedErr := ResolveNamed[*testContainerCycleA](container, "other")
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "cycle", err: cycleErr,
+			want: "dependency cycle: [*bvmgo_reflect.testContainerCycleA] -> [*bvmgo_reflect.testContainerCycleB] -> [*bvmgo_reflect.testContainerCycleA]"},
+		{name: "missing", err: missingErr,
+			want: "[*bvmgo_reflect.testContainerConfig] dependency is not provided (required by [*bvmgo_reflect.testContainerDatabase])"},
+		{name: "named", err: namedErr, want: "[*bvmgo_reflect.testContainerCycleA[other]] dependency is not provided"},
+		{name: "duplicate", err: container.Provide(func() *testContainerCycleA { return nil }),
+			want: "[*bvmgo_reflect.testContainerCycleA] dependency is already provided"},
+		{name: "not a constructor", err: container.Provide(func() {}),
+			want: "constructor must return an instance and optionally an error"},
+		{name: "variadic constructor", err: container.Provide(func(_ ...*testContainerConfig) int { return 0 }),
+			want: "constructor is variadic, variadic constructors are not supported"},
+		{name: "variadic function", err: func() error {
+			_, err := container.Invoke(func(_ ...*testContainerConfig) {})
+			return err
+		}(), want: "function is variadic, variadic functions are not supported"},
+		{name: "constructor error", err: func() error {
+			_ = container.ProvideWith(func() (string, error) { return "", errors.New("failure") }, ProvideOptions{})
+			_, err := Resolve[string](container)
+			return err
+		}(), want: "[string] dependency constructor returns an error: failure"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Errorf("returns nil (no error), want an error")
+				return
+			}
+			if !strings.Contains(tt.err.Error(), tt.want) {
+				t.Errorf("err.Error() = [%v], want contain [%v]", tt.err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestContainer_constructorPanic(t *testing.T) {
+	container := NewContainer()
+	_ = container.Provide(func() *testContainerConfig { panic("failure") })
+	func() {
+		defer func() { _ = recover() }()
+		_, _ = container.Invoke(func(_ *testContainerConfig) {})
+	}()
+	done := make(chan error, 1)
+	go func() {
+		done <- container.ProvideWith(func() *testContainerConfig { return nil }, ProvideOptions{Name: "other"})
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("ProvideWith(...) returns \"%v\" error, want no error", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("ProvideWith(...) is blocked after a constructor panic")
+	}
+}
+
+func TestContainer_constructorResolves(t *testing.T) {
+	container := testContainer(t)
+	_ = container.Provide(func() (*testContainerService, error) {
+		database, err := Resolve[*testContainerDatabase](container)
+		return &testContainerService{Database: database}, err
+	})
+	service, err := Resolve[*testContainerService](container)
+	if err != nil || service.Database == nil || service.Database.Config.URL != "default" {
+		t.Errorf("Resolve() = [%+v, %v], want a service with a database", service, err)
+	}
+}
@@ -0,0 +1,223 @@
+package bvmgo_reflect
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// csvColumn describes a structure field mapped to a CSV column.
+type csvColumn struct {
+	name      string
+	fieldName string
+	index     []int
+}
+
+// MarshalCSV function writes records to writer as CSV, with a header row.
+//
+// Columns are named from "csv" tag (`csv:"name"`), or from field name if no tag is defined.
+// Fields tagged `csv:"-"` and unexported fields are ignored, fields of embedded structures (and structure pointers)
+// are promoted. Values are formatted with encoding.TextMarshaler if implemented, nil pointers (including nil embedded
+// structure pointers) are written as empty cells.
+//
+// MarshalCSV function returns an error if:
+//   - T is not a structure (or a pointer to a structure),
+//   - a field type is not supported,
+//   - writer returns an error.
+func MarshalCSV[T any](writer io.Writer, records []T) error {
+	recordType := reflect.TypeOf((*T)(nil)).Elem()
+	columns, err := csvColumns(recordType)
+	if err != nil {
+		return err
+	}
+	csvWriter := csv.NewWriter(writer)
+	header := make([]string, len(columns))
+	for index, column := range columns {
+		header[index] = column.name
+	}
+	if err = csvWriter.Write(header); err != nil {
+		return err
+	}
+	row := make([]string, len(columns))
+	for recordIndex := range records {
+		recordValue := reflect.ValueOf(&records[recordIndex]).Elem()
+		if recordValue.Kind() == reflect.Ptr {
+			if recordValue.IsNil() {
+				return fmt.Errorf("CSV record [%d] is nil", recordIndex)
+			}
+			recordValue = recordValue.Elem()
+		}
+		for columnIndex, column := range columns {
+			cell, err := formatCSVCell(recordValue, column.index)
+			if err != nil {
+				return fmt.Errorf("CSV record [%d] column [%s]: [%s.%s] field cannot be formatted: %w",
+					recordIndex, column.name, typeName(recordValue.Type()), column.fieldName, err)
+			}
+			row[columnIndex] = cell
+		}
+		if err = csvWriter.Write(row); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// UnmarshalCSV function reads CSV from reader and appends a record per row to records.
+//
+// The first row is the header: columns are mapped to structure fields by "csv" tag,
+// or by field name (case-insensitive) if no tag is defined. Unknown columns are ignored.
+// Cells are parsed according to field type (see SetFieldFromString function), empty cells are zero values.
+// Nil embedded structure pointers are allocated when one of their promoted fields has a non-empty cell
+// (like encoding/json, pointers to unexported structures cannot be allocated).
+//
+// UnmarshalCSV function returns an error if:
+//   - records is nil,
+//   - T is not a structure (or a pointer to a structure),
+//   - reader content is not a valid CSV,
+//   - a cell cannot be parsed to field type (error contains line and column).
+func UnmarshalCSV[T any](reader io.Reader, records *[]T) error {
+	if records == nil {
+		return fmt.Errorf("a not nil pointer is required to unmarshal CSV")
+	}
+	recordType := reflect.TypeOf((*T)(nil)).Elem()
+	columns, err := csvColumns(recordType)
+	if err != nil {
+		return err
+	}
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	// Map header cells to columns
+	headerColumns := make([]*csvColumn, len(header))
+	for cellIndex, cell := range header {
+		cleanCell := strings.TrimSpace(cell)
+		for columnIndex := range columns {
+			if columns[columnIndex].name == cleanCell {
+				headerColumns[cellIndex] = &columns[columnIndex]
+				break
+			}
+		}
+		if headerColumns[cellIndex] != nil {
+			continue
+		}
+		for columnIndex := range columns {
+			if strings.EqualFold(columns[columnIndex].name, cleanCell) ||
+				strings.EqualFold(columns[columnIndex].fieldName, cleanCell) {
+				headerColumns[cellIndex] = &columns[columnIndex]
+				break
+			}
+		}
+	}
+	for {
+		row, err := csvReader.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		line, _ := csvReader.FieldPos(0)
+		record := reflect.New(recordType).Elem()
+		recordValue := record
+		if recordType.Kind() == reflect.Ptr {
+			record.Set(reflect.New(recordType.Elem()))
+			recordValue = record.Elem()
+		}
+		for cellIndex, cell := range row {
+			if cellIndex >= len(headerColumns) || headerColumns[cellIndex] == nil {
+				continue
+			}
+			column := headerColumns[cellIndex]
+			var err error
+			if len(cell) == 0 {
+				// Empty cell: default "zero" value (nil embedded structure pointers are kept nil)
+				if fieldValue, nilErr := recordValue.FieldByIndexErr(column.index); nilErr == nil {
+					fieldValue.Set(reflect.Zero(fieldValue.Type()))
+				}
+			} else {
+				var fieldValue reflect.Value
+				if fieldValue, err = fieldByIndexAlloc(recordValue, column.index); err == nil {
+					err = setStringToReflectValue(fieldValue, cell)
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("CSV line [%d] column [%d] (%s): [%s.%s] field cannot be set with [%s] text: %w",
+					line, cellIndex+1, column.name, typeName(recordValue.Type()), column.fieldName, cell, err)
+			}
+		}
+		*records = append(*records, record.Interface().(T))
+	}
+}
+
+// csvColumns function returns the columns of a record type.
+func csvColumns(recordType reflect.Type) ([]csvColumn, error) {
+	structType := recordType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported type [%s], a structure is required to map CSV columns",
+			typeName(recordType))
+	}
+	var columns []csvColumn
+	for _, field := range reflect.VisibleFields(structType) {
+		tag := field.Tag.Get("csv")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		// Embedded structures (and structure pointers) are flattened by reflect.VisibleFields
+		if field.Anonymous && len(name) == 0 && isEmbeddedCSVStruct(field.Type) {
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+		columns = append(columns, csvColumn{name: name, fieldName: field.Name, index: field.Index})
+	}
+	return columns, nil
+}
+
+// isEmbeddedCSVStruct function returns true if an embedded field type is a structure (or a pointer to a structure)
+// whose fields are promoted to columns.
+func isEmbeddedCSVStruct(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType.Kind() == reflect.Struct && !reflect.PointerTo(fieldType).Implements(textUnmarshalerType)
+}
+
+// formatCSVCell function returns the text of the field at index path.
+func formatCSVCell(recordValue reflect.Value, index []int) (string, error) {
+	fieldValue, err := recordValue.FieldByIndexErr(index)
+	if err != nil {
+		// Field of a nil embedded pointer
+		return "", nil
+	}
+	for fieldValue.Kind() == reflect.Ptr || fieldValue.Kind() == reflect.Interface {
+		if fieldValue.IsNil() {
+			return "", nil
+		}
+		if text, ok, err := marshalText(fieldValue); ok || err != nil {
+			return text, err
+		}
+		fieldValue = fieldValue.Elem()
+	}
+	if text, ok, err := marshalText(fieldValue); ok || err != nil {
+		return text, err
+	}
+	if fieldValue.Type() == durationType {
+		return fieldValue.Interface().(fmt.Stringer).String(), nil
+	}
+	return formatScalar(fieldValue)
+}
@@ -0,0 +1,156 @@
+package bvmgo_reflect
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testCSVBase struct {
+	ID int `csv:"id"`
+}
+
+type testCSVRecord struct {
+	testCSVBase
+	Name     string        `csv:"name"`
+	Score    float64       `csv:"score"`
+	Active   bool          `csv:"active"`
+	Comment  *string       `csv:"comment"`
+	Since    time.Time     `csv:"since"`
+	Timeout  time.Duration `csv:"timeout"`
+	Ignored  string        `csv:"-"`
+	NoTag    int
+	internal string
+}
+
+func TestMarshalCSV(t *testing.T) {
+	comment := "hello, world"
+	records := []testCSVRecord{
+		{testCSVBase: testCSVBase{ID: 1}, Name: "alice", Score: 12.5, Active: true, Comment: &comment,
+			Since: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Timeout: time.Second, NoTag: 7},
+		{testCSVBase: testCSVBase{ID: 2}, Name: "bob", Since: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	var buffer bytes.Buffer
+	if err := MarshalCSV(&buffer, records); err != nil {
+		t.Errorf("MarshalCSV(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	expected := "id,name,score,active,comment,since,timeout,NoTag\n" +
+		"1,alice,12.5,true,\"hello, world\",2024-01-02T03:04:05Z,1s,7\n" +
+		"2,bob,0,false,,2023-01-01T00:00:00Z,0s,0\n"
+	if buffer.String() != expected {
+		t.Errorf("MarshalCSV(...) = [%v], want [%v]", buffer.String(), expected)
+	}
+}
+
+func TestUnmarshalCSV(t *testing.T) {
+	input := "name,id,unknown,comment,notag,active,timeout\n" +
+		"alice,1,x,hi,7,true,2m\n" +
+		"bob,2,y,,,false,0s\n"
+	var records []testCSVRecord
+	if err := UnmarshalCSV(strings.NewReader(input), &records); err != nil {
+		t.Errorf("UnmarshalCSV(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	comment := "hi"
+	expected := []testCSVRecord{
+		{testCSVBase: testCSVBase{ID: 1}, Name: "alice", Comment: &comment, NoTag: 7, Active: true, Timeout: 2 * time.Minute},
+		{testCSVBase: testCSVBase{ID: 2}, Name: "bob"},
+	}
+	if report := Diff(records, expected); !report.Equal() {
+		t.Errorf("UnmarshalCSV(...) differs from expected:\n%s", report)
+	}
+}
+
+func TestUnmarshalCSV_roundTripPointers(t *testing.T) {
+	records := []*testCSVRecord{{Name: "carol", Score: 3.25, Since: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)}}
+	var buffer bytes.Buffer
+	if err := MarshalCSV(&buffer, records); err != nil {
+		t.Errorf("MarshalCSV(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	var decoded []*testCSVRecord
+	if err := UnmarshalCSV(&buffer, &decoded); err != nil {
+		t.Errorf("UnmarshalCSV(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if len(decoded) != 1 || !reflect.DeepEqual(*decoded[0], *records[0]) {
+		t.Errorf("UnmarshalCSV(...) = [%+v], want [%+v]", decoded, records)
+	}
+}
+
+// TestCSVBase is an exported alias: pointers to unexported embedded structures cannot be allocated.
+type TestCSVBase = testCSVBase
+
+type testCSVPointerRecord struct {
+	*TestCSVBase
+	Name string `csv:"name"`
+}
+
+type testCSVUnexportedPointerRecord struct {
+	*testCSVBase
+	Name string `csv:"name"`
+}
+
+func TestMarshalCSV_embeddedPointer(t *testing.T) {
+	records := []testCSVPointerRecord{
+		{TestCSVBase: &TestCSVBase{ID: 1}, Name: "alice"},
+		{Name: "bob"},
+		{TestCSVBase: &TestCSVBase{}, Name: "carol"},
+	}
+	var buffer bytes.Buffer
+	if err := MarshalCSV(&buffer, records); err != nil {
+		t.Errorf("MarshalCSV(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	expected := "id,name\n1,alice\n,bob\n0,carol\n"
+	if buffer.String() != expected {
+		t.Errorf("MarshalCSV(...) = [%v], want [%v]", buffer.String(), expected)
+	}
+	var decoded []testCSVPointerRecord
+	if err := UnmarshalCSV(&buffer, &decoded); err != nil {
+		t.Errorf("UnmarshalCSV(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if report := Diff(decoded, records); !report.Equal() {
+		t.Errorf("UnmarshalCSV(...) differs from expected:\n%s", report)
+	}
+}
+
+func TestUnmarshalCSV_unexportedEmbeddedPointer(t *testing.T) {
+	var records []testCSVUnexportedPointerRecord
+	err := UnmarshalCSV(strings.NewReader("id,name\n,bob\n1,alice\n"), &records)
+	if err == nil || !strings.Contains(err.Error(), "[*bvmgo_reflect.testCSVBase] embedded pointer is not settable") {
+		t.Errorf("UnmarshalCSV(...) error = [%v], want contain [%v]", err, "embedded pointer is not settable")
+	}
+	if len(records) != 1 || records[0].Name != "bob" {
+		t.Errorf("records = %+v, want [bob] record", records)
+	}
+}
+
+func TestUnmarshalCSV_parseError(t *testing.T) {
+	input := "id,name\n1,alice\nabc,bob\n"
+	var records []testCSVRecord
+	err := UnmarshalCSV(strings.NewReader(input), &records)
+	if err == nil {
+		t.Errorf("UnmarshalCSV(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "CSV line [3] column [1] (id)") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "CSV line [3] column [1] (id)")
+	}
+}
+
+func TestMarshalCSV_notStruct(t *testing.T) {
+	var buffer bytes.Buffer
+	err := MarshalCSV(&buffer, []int{1, 2})
+	if err == nil {
+		t.Errorf("MarshalCSV(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "a structure is required") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "a structure is required")
+	}
+}
@@ -0,0 +1,241 @@
+package bvmgo_reflect
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TypeDescription describes a type: its fields (structures) and its methods.
+//
+// Descriptions are cached and shared: they must not be modified.
+type TypeDescription struct {
+	// Type is the described type.
+	Type reflect.Type
+	// Name is the type name (see TypeName).
+	Name string
+	// Fields are the visible fields of the structure (or of the pointed structure), promoted fields included.
+	Fields []FieldDescription
+	// Methods are the methods of the type and of its pointer, sorted by name.
+	Methods []MethodDescription
+}
+
+// FieldDescription describes a structure field.
+type FieldDescription struct {
+	// Name is the field name.
+	Name string
+	// Type is the field type.
+	Type reflect.Type
+	// TypeName is the field type name (see TypeName).
+	TypeName string
+	// Index is the index path of the field (see reflect.Value.FieldByIndex).
+	Index []int
+	// Tags are the parsed field tags, in declaration order.
+	Tags []TagDescription
+	// Exported is true if the field is exported.
+	Exported bool
+	// Embedded is true if the field is an embedded field.
+	Embedded bool
+	// Promoted is true if the field is promoted from an embedded structure.
+	Promoted bool
+}
+
+// TagDescription describes a field tag (`key:"name,option1,option2"`).
+type TagDescription struct {
+	// Key is the tag key ("json").
+	Key string
+	// Value is the raw tag value ("name,omitempty").
+	Value string
+	// Name is the first element of the tag value ("name").
+	Name string
+	// Options are the next elements of the tag value (["omitempty"]).
+	Options []string
+}
+
+// MethodDescription describes a method.
+type MethodDescription struct {
+	// Name is the method name.
+	Name string
+	// Type is the method function type, without receiver.
+	Type reflect.Type
+	// Signature is the method signature ("(string, int) error").
+	Signature string
+	// PointerReceiver is true if the method is only available on the pointer of the type.
+	PointerReceiver bool
+}
+
+// descriptions caches type descriptions (reflect.Type -> *TypeDescription).
+var descriptions sync.Map
+
+// Describe function returns the description of a type (nil if describedType is nil).
+//
+// If describedType is a pointer to a structure, fields of the pointed structure are described.
+// Methods are the methods of describedType and of its pointer (or of its pointed type).
+func Describe(describedType reflect.Type) *TypeDescription {
+	if describedType == nil {
+		return nil
+	}
+	if description, ok := descriptions.Load(describedType); ok {
+		return description.(*TypeDescription)
+	}
+	description := &TypeDescription{Type: describedType, Name: typeName(describedType)}
+	structType := describedType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() == reflect.Struct {
+		for _, field := range reflect.VisibleFields(structType) {
+			description.Fields = append(description.Fields, FieldDescription{
+				Name:     field.Name,
+				Type:     field.Type,
+				TypeName: typeName(field.Type),
+				Index:    field.Index,
+				Tags:     parseTags(field.Tag),
+				Exported: field.IsExported(),
+				Embedded: field.Anonymous,
+				Promoted: len(field.Index) > 1,
+			})
+		}
+	}
+	description.Methods = describeMethods(describedType)
+	actual, _ := descriptions.LoadOrStore(describedType, description)
+	return actual.(*TypeDescription)
+}
+
+// DescribeOf function returns the description of T type.
+//
+// See Describe function.
+func DescribeOf[T any]() *TypeDescription {
+	return Describe(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// Field function returns the description of the "fieldName" field.
+func (description *TypeDescription) Field(fieldName string) (FieldDescription, bool) {
+	cleanFieldName := strings.TrimSpace(fieldName)
+	for _, field := range description.Fields {
+		if field.Name == cleanFieldName {
+			return field, true
+		}
+	}
+	return FieldDescription{}, false
+}
+
+// Method function returns the description of the "methodName" method.
+func (description *TypeDescription) Method(methodName string) (MethodDescription, bool) {
+	cleanMethodName := strings.TrimSpace(methodName)
+	for _, method := range description.Methods {
+		if method.Name == cleanMethodName {
+			return method, true
+		}
+	}
+	return MethodDescription{}, false
+}
+
+// Tag function returns the description of the tag with key.
+func (field FieldDescription) Tag(key string) (TagDescription, bool) {
+	for _, tag := range field.Tags {
+		if tag.Key == key {
+			return tag, true
+		}
+	}
+	return TagDescription{}, false
+}
+
+// HasOption function returns true if the tag value contains option.
+func (tag TagDescription) HasOption(option string) bool {
+	for _, tagOption := range tag.Options {
+		if tagOption == option {
+			return true
+		}
+	}
+	return false
+}
+
+// describeMethods function returns the methods of a type and of its pointer (or pointed type).
+func describeMethods(describedType reflect.Type) []MethodDescription {
+	valueType, pointerType := describedType, reflect.PointerTo(describedType)
+	if describedType.Kind() == reflect.Ptr {
+		valueType, pointerType = describedType.Elem(), describedType
+	}
+	if valueType.Kind() == reflect.Interface {
+		pointerType = valueType
+	}
+	formatter := typeNameFormatter{}
+	var methods []MethodDescription
+	for index := 0; index < pointerType.NumMethod(); index++ {
+		method := pointerType.Method(index)
+		methodType := method.Type
+		if pointerType.Kind() != reflect.Interface {
+			methodType = withoutReceiver(methodType)
+		}
+		_, isValueMethod := valueType.MethodByName(method.Name)
+		methods = append(methods, MethodDescription{
+			Name:            method.Name,
+			Type:            methodType,
+			Signature:       formatter.formatSignature(methodType),
+			PointerReceiver: !isValueMethod,
+		})
+	}
+	return methods
+}
+
+// withoutReceiver function returns a method function type without its first (receiver) parameter.
+func withoutReceiver(methodType reflect.Type) reflect.Type {
+	parameters := make([]reflect.Type, methodType.NumIn()-1)
+	for index := range parameters {
+		parameters[index] = methodType.In(index + 1)
+	}
+	results := make([]reflect.Type, methodType.NumOut())
+	for index := range results {
+		results[index] = methodType.Out(index)
+	}
+	return reflect.FuncOf(parameters, results, methodType.IsVariadic())
+}
+
+// parseTags function returns the tags of a structure field (conventional `key:"value" key2:"value2"` format).
+func parseTags(structTag reflect.StructTag) []TagDescription {
+	var tags []TagDescription
+	tag := string(structTag)
+	for tag != "" {
+		// Skip leading spaces
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			break
+		}
+		// Scan to colon: key is a sequence of non-control, non-space, non-quote, non-colon characters
+		keyEnd := 0
+		for keyEnd < len(tag) && tag[keyEnd] > ' ' && tag[keyEnd] != ':' && tag[keyEnd] != '"' && tag[keyEnd] != 0x7f {
+			keyEnd++
+		}
+		if keyEnd == 0 || keyEnd+1 >= len(tag) || tag[keyEnd] != ':' || tag[keyEnd+1] != '"' {
+			break
+		}
+		key := tag[:keyEnd]
+		tag = tag[keyEnd+1:]
+		// Scan quoted string to find value
+		valueEnd := 1
+		for valueEnd < len(tag) && tag[valueEnd] != '"' {
+			if tag[valueEnd] == '\\' {
+				valueEnd++
+			}
+			valueEnd++
+		}
+		if valueEnd >= len(tag) {
+			break
+		}
+		quotedValue := tag[:valueEnd+1]
+		tag = tag[valueEnd+1:]
+		value, err := strconv.Unquote(quotedValue)
+		if err != nil {
+			break
+		}
+		name, options, _ := strings.Cut(value, ",")
+		tagDescription := TagDescription{Key: key, Value: value, Name: name}
+		if len(options) > 0 {
+			tagDescription.Options = strings.Split(options, ",")
+		}
+		tags = append(tags, tagDescription)
+	}
+	return tags
+}
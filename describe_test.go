@@ -0,0 +1,103 @@
+package bvmgo_reflect
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testDescribeBase struct {
+	ID int `json:"id" db:"id,pk"`
+}
+
+func (base testDescribeBase) Identifier() int {
+	return base.ID
+}
+
+type testDescribeStruct struct {
+	testDescribeBase
+	Name    string `json:"name,omitempty" validate:"required"`
+	private bool
+}
+
+func (test *testDescribeStruct) Rename(name string, force ...bool) error {
+	test.Name = name
+	return nil
+}
+
+func TestDescribe_fields(t *testing.T) {
+	description := Describe(reflect.TypeOf(testDescribeStruct{}))
+	if description.Name != "bvmgo_reflect.testDescribeStruct" {
+		t.Errorf("Describe().Name = %v, want %v", description.Name, "bvmgo_reflect.testDescribeStruct")
+	}
+	names := make([]string, len(description.Fields))
+	for index, field := range description.Fields {
+		names[index] = field.Name
+	}
+	expectedNames := []string{"testDescribeBase", "ID", "Name", "private"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("Describe().Fields names = %v, want %v", names, expectedNames)
+	}
+	id, _ := description.Field("ID")
+	expectedID := FieldDescription{Name: "ID", Type: reflect.TypeOf(0), TypeName: "int", Index: []int{0, 0},
+		Tags: []TagDescription{
+			{Key: "json", Value: "id", Name: "id"},
+			{Key: "db", Value: "id,pk", Name: "id", Options: []string{"pk"}},
+		},
+		Exported: true, Promoted: true}
+	if !reflect.DeepEqual(id, expectedID) {
+		t.Errorf("Describe().Field(ID) = %+v, want %+v", id, expectedID)
+	}
+	base, _ := description.Field("testDescribeBase")
+	if !base.Embedded || base.Exported || base.Promoted {
+		t.Errorf("Describe().Field(testDescribeBase) = %+v, want embedded, not exported, not promoted", base)
+	}
+	name, _ := description.Field("Name")
+	if tag, ok := name.Tag("json"); !ok || tag.Name != "name" || !tag.HasOption("omitempty") {
+		t.Errorf("Describe().Field(Name).Tag(json) = %+v, want name with omitempty option", tag)
+	}
+	if _, ok := description.Field("unknown"); ok {
+		t.Errorf("Describe().Field(unknown) returns true, want false")
+	}
+}
+
+func TestDescribe_methods(t *testing.T) {
+	description := DescribeOf[*testDescribeStruct]()
+	expected := []MethodDescription{
+		{Name: "Identifier", Type: reflect.TypeOf(func() int { return 0 }), Signature: "() int"},
+		{Name: "Rename", Type: reflect.TypeOf(func(string, ...bool) error { return nil }),
+			Signature: "(string, ...bool) error", PointerReceiver: true},
+	}
+	if !reflect.DeepEqual(description.Methods, expected) {
+		t.Errorf("Describe().Methods = %+v, want %+v", description.Methods, expected)
+	}
+	if len(description.Fields) != 4 {
+		t.Errorf("len(Describe().Fields) = %v, want %v", len(description.Fields), 4)
+	}
+}
+
+func TestDescribe_interface(t *testing.T) {
+	description := DescribeOf[testShape]()
+	if method, ok := description.Method("Area"); !ok || method.Signature != "() float64" || method.PointerReceiver {
+		t.Errorf("Describe().Method(Area) = %+v, want () float64 value method", method)
+	}
+}
+
+func TestDescribe_cache(t *testing.T) {
+	if Describe(reflect.TypeOf(testDescribeStruct{})) != Describe(reflect.TypeOf(testDescribeStruct{})) {
+		t.Errorf("Describe() returns different descriptions, want the cached description")
+	}
+	if Describe(nil) != nil {
+		t.Errorf("Describe(nil) = not nil, want nil")
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	tags := parseTags(`json:"a,omitempty"  xml:"b" bad`)
+	expected := []TagDescription{
+		{Key: "json", Value: "a,omitempty", Name: "a", Options: []string{"omitempty"}},
+		{Key: "xml", Value: "b", Name: "b"},
+	}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("parseTags() = %+v, want %+v", tags, expected)
+	}
+}
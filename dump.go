@@ -0,0 +1,370 @@
+package bvmgo_reflect
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DumpOptions configures DumpWith and FdumpWith functions.
+type DumpOptions struct {
+	// MaxDepth is the maximum depth of nested values (0 is unlimited).
+	MaxDepth int
+	// MaxLength is the maximum number of elements of slices, arrays, maps and runes of strings (0 is unlimited).
+	MaxLength int
+	// SortMapKeys sorts map entries by key for a deterministic output.
+	SortMapKeys bool
+	// Indent is the indentation of nested values (two spaces if empty).
+	Indent string
+}
+
+// Dump function returns an indented, Go-syntax-like representation of a value.
+//
+// Values are rendered with their type name (see TypeName), structure field names (unexported fields included)
+// and pointers as "&". Pointers referenced several times are annotated with their address,
+// next references and cycles are rendered as "(*T)(0xaddress)" with a comment.
+func Dump(value any) string {
+	return DumpWith(value, DumpOptions{})
+}
+
+// DumpWith function returns an indented, Go-syntax-like representation of a value with options.
+//
+// See Dump function.
+func DumpWith(value any, options DumpOptions) string {
+	var builder strings.Builder
+	_ = FdumpWith(&builder, value, options)
+	return builder.String()
+}
+
+// Fdump function writes an indented, Go-syntax-like representation of a value to writer.
+//
+// See Dump function. Fdump function returns writer errors.
+func Fdump(writer io.Writer, value any) error {
+	return FdumpWith(writer, value, DumpOptions{})
+}
+
+// FdumpWith function writes an indented, Go-syntax-like representation of a value to writer with options.
+//
+// See Dump function. FdumpWith function returns writer errors.
+func FdumpWith(writer io.Writer, value any, options DumpOptions) error {
+	if len(options.Indent) == 0 {
+		options.Indent = "  "
+	}
+	dumper := valueDumper{
+		options:   options,
+		pointers:  make(map[visitedPointer]int),
+		visiting:  make(map[visitedPointer]bool),
+		displayed: make(map[visitedPointer]bool),
+	}
+	rootValue := reflect.ValueOf(value)
+	dumper.countPointers(rootValue, make(map[visitedPointer]bool))
+	dumper.dump(rootValue, 0)
+	dumper.builder.WriteString("\n")
+	_, err := io.WriteString(writer, dumper.builder.String())
+	return err
+}
+
+// valueDumper renders values in a builder.
+type valueDumper struct {
+	options   DumpOptions
+	builder   strings.Builder
+	pointers  map[visitedPointer]int
+	visiting  map[visitedPointer]bool
+	displayed map[visitedPointer]bool
+}
+
+// referenceKey function returns the key of a pointer, a map or a slice (address and type).
+func referenceKey(value reflect.Value) visitedPointer {
+	return visitedPointer{pointer: value.Pointer(), pointerType: value.Type()}
+}
+
+// countPointers function counts references of each pointer (maps and slices are visited once).
+func (dumper *valueDumper) countPointers(value reflect.Value, visited map[visitedPointer]bool) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return
+		}
+		key := referenceKey(value)
+		dumper.pointers[key]++
+		if visited[key] {
+			return
+		}
+		visited[key] = true
+		dumper.countPointers(value.Elem(), visited)
+	case reflect.Interface:
+		dumper.countPointers(value.Elem(), visited)
+	case reflect.Struct:
+		for index := 0; index < value.NumField(); index++ {
+			dumper.countPointers(value.Field(index), visited)
+		}
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.Len() > 0 {
+			key := referenceKey(value)
+			if visited[key] {
+				return
+			}
+			visited[key] = true
+		}
+		for index := 0; index < value.Len(); index++ {
+			dumper.countPointers(value.Index(index), visited)
+		}
+	case reflect.Map:
+		if value.IsNil() {
+			return
+		}
+		key := referenceKey(value)
+		if visited[key] {
+			return
+		}
+		visited[key] = true
+		iterator := value.MapRange()
+		for iterator.Next() {
+			dumper.countPointers(iterator.Key(), visited)
+			dumper.countPointers(iterator.Value(), visited)
+		}
+	}
+}
+
+// newLine function starts a new line with the indentation of depth.
+func (dumper *valueDumper) newLine(depth int) {
+	dumper.builder.WriteString("\n")
+	dumper.builder.WriteString(strings.Repeat(dumper.options.Indent, depth))
+}
+
+// isTooDeep function returns true if depth exceeds the maximum depth.
+func (dumper *valueDumper) isTooDeep(depth int) bool {
+	return dumper.options.MaxDepth > 0 && depth >= dumper.options.MaxDepth
+}
+
+// truncatedLength function returns the number of elements to render.
+func (dumper *valueDumper) truncatedLength(length int) int {
+	if dumper.options.MaxLength > 0 && length > dumper.options.MaxLength {
+		return dumper.options.MaxLength
+	}
+	return length
+}
+
+// writeTruncated function writes the truncation marker of remaining elements.
+func (dumper *valueDumper) writeTruncated(remaining int, depth int) {
+	if remaining > 0 {
+		dumper.newLine(depth + 1)
+		fmt.Fprintf(&dumper.builder, "// ... %d more", remaining)
+	}
+}
+
+// dump function renders value at depth.
+func (dumper *valueDumper) dump(value reflect.Value, depth int) {
+	if !value.IsValid() {
+		dumper.builder.WriteString("nil")
+		return
+	}
+	valueType := value.Type()
+	// time.Time and similar types are more readable with their String method
+	if valueType == timeType && value.CanInterface() {
+		fmt.Fprintf(&dumper.builder, "%s(%q)", typeName(valueType), value.Interface())
+		return
+	}
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			dumper.builder.WriteString("nil")
+			return
+		}
+		dumper.dump(value.Elem(), depth)
+	case reflect.Ptr:
+		dumper.dumpPointer(value, depth)
+	case reflect.Struct:
+		dumper.dumpStruct(value, depth)
+	case reflect.Slice, reflect.Array:
+		dumper.dumpList(value, depth)
+	case reflect.Map:
+		dumper.dumpMap(value, depth)
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if value.IsNil() {
+			fmt.Fprintf(&dumper.builder, "(%s)(nil)", typeName(valueType))
+		} else {
+			fmt.Fprintf(&dumper.builder, "(%s)(0x%x)", typeName(valueType), value.Pointer())
+		}
+	case reflect.String:
+		text := value.String()
+		length := dumper.truncatedLength(len([]rune(text)))
+		dumper.writeScalar(valueType, strconv.Quote(string([]rune(text)[:length])))
+		if length < len([]rune(text)) {
+			fmt.Fprintf(&dumper.builder, " /* ... %d more */", len([]rune(text))-length)
+		}
+	default:
+		text, err := formatScalar(value)
+		if err != nil {
+			text = "?"
+		}
+		dumper.writeScalar(valueType, text)
+	}
+}
+
+// writeScalar function writes a scalar literal, converted to its type if type is named.
+func (dumper *valueDumper) writeScalar(valueType reflect.Type, literal string) {
+	if len(valueType.PkgPath()) > 0 || valueType.Name() != valueType.Kind().String() {
+		fmt.Fprintf(&dumper.builder, "%s(%s)", typeName(valueType), literal)
+		return
+	}
+	dumper.builder.WriteString(literal)
+}
+
+// dumpPointer function renders a pointer (shared references and cycles are annotated).
+func (dumper *valueDumper) dumpPointer(value reflect.Value, depth int) {
+	if value.IsNil() {
+		fmt.Fprintf(&dumper.builder, "(%s)(nil)", typeName(value.Type()))
+		return
+	}
+	key := referenceKey(value)
+	if dumper.writeCycle(key) {
+		return
+	}
+	if dumper.displayed[key] {
+		fmt.Fprintf(&dumper.builder, "(%s)(0x%x) // shared", typeName(value.Type()), key.pointer)
+		return
+	}
+	dumper.displayed[key] = true
+	dumper.visiting[key] = true
+	defer delete(dumper.visiting, key)
+	dumper.builder.WriteString("&")
+	if dumper.pointers[key] > 1 {
+		fmt.Fprintf(&dumper.builder, "/* 0x%x */ ", key.pointer)
+	}
+	dumper.dump(value.Elem(), depth)
+}
+
+// writeCycle function writes the cycle marker if the pointer, map or slice of key is being rendered.
+func (dumper *valueDumper) writeCycle(key visitedPointer) bool {
+	if !dumper.visiting[key] {
+		return false
+	}
+	fmt.Fprintf(&dumper.builder, "(%s)(0x%x) // cycle", typeName(key.pointerType), key.pointer)
+	return true
+}
+
+// dumpStruct function renders a structure with its fields.
+func (dumper *valueDumper) dumpStruct(value reflect.Value, depth int) {
+	valueType := value.Type()
+	dumper.builder.WriteString(typeName(valueType))
+	if value.NumField() == 0 {
+		dumper.builder.WriteString("{}")
+		return
+	}
+	if dumper.isTooDeep(depth) {
+		dumper.builder.WriteString("{...}")
+		return
+	}
+	dumper.builder.WriteString("{")
+	for index := 0; index < value.NumField(); index++ {
+		dumper.newLine(depth + 1)
+		dumper.builder.WriteString(valueType.Field(index).Name)
+		dumper.builder.WriteString(": ")
+		dumper.dump(value.Field(index), depth+1)
+		dumper.builder.WriteString(",")
+	}
+	dumper.newLine(depth)
+	dumper.builder.WriteString("}")
+}
+
+// dumpList function renders a slice or an array with its elements.
+func (dumper *valueDumper) dumpList(value reflect.Value, depth int) {
+	if value.Kind() == reflect.Slice && value.Len() > 0 {
+		key := referenceKey(value)
+		if dumper.writeCycle(key) {
+			return
+		}
+		dumper.visiting[key] = true
+		defer delete(dumper.visiting, key)
+	}
+	dumper.builder.WriteString(typeName(value.Type()))
+	if value.Kind() == reflect.Slice && value.IsNil() {
+		dumper.builder.WriteString("(nil)")
+		return
+	}
+	if value.Len() == 0 {
+		dumper.builder.WriteString("{}")
+		return
+	}
+	if dumper.isTooDeep(depth) {
+		dumper.builder.WriteString("{...}")
+		return
+	}
+	dumper.builder.WriteString("{")
+	length := dumper.truncatedLength(value.Len())
+	for index := 0; index < length; index++ {
+		dumper.newLine(depth + 1)
+		dumper.dump(value.Index(index), depth+1)
+		dumper.builder.WriteString(",")
+	}
+	dumper.writeTruncated(value.Len()-length, depth)
+	dumper.newLine(depth)
+	dumper.builder.WriteString("}")
+}
+
+// dumpMap function renders a map with its entries.
+func (dumper *valueDumper) dumpMap(value reflect.Value, depth int) {
+	if !value.IsNil() {
+		key := referenceKey(value)
+		if dumper.writeCycle(key) {
+			return
+		}
+		dumper.visiting[key] = true
+		defer delete(dumper.visiting, key)
+	}
+	dumper.builder.WriteString(typeName(value.Type()))
+	if value.IsNil() {
+		dumper.builder.WriteString("(nil)")
+		return
+	}
+	if value.Len() == 0 {
+		dumper.builder.WriteString("{}")
+		return
+	}
+	if dumper.isTooDeep(depth) {
+		dumper.builder.WriteString("{...}")
+		return
+	}
+	keys := value.MapKeys()
+	if dumper.options.SortMapKeys {
+		sortValues(keys)
+	}
+	dumper.builder.WriteString("{")
+	length := dumper.truncatedLength(len(keys))
+	for _, key := range keys[:length] {
+		dumper.newLine(depth + 1)
+		dumper.dump(key, depth+1)
+		dumper.builder.WriteString(": ")
+		dumper.dump(value.MapIndex(key), depth+1)
+		dumper.builder.WriteString(",")
+	}
+	dumper.writeTruncated(len(keys)-length, depth)
+	dumper.newLine(depth)
+	dumper.builder.WriteString("}")
+}
+
+// sortValues function sorts values (map keys): numbers by value, others by their representation.
+func sortValues(values []reflect.Value) {
+	slices.SortStableFunc(values, compareValues)
+}
+
+// compareValues function compares two values of the same type (-1, 0 or +1).
+func compareValues(a reflect.Value, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	default:
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
@@ -0,0 +1,133 @@
+package bvmgo_reflect
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testDumpLevel int
+
+type testDumpNode struct {
+	Name     string
+	Level    testDumpLevel
+	Tags     []string
+	Values   map[string]int
+	Next     *testDumpNode
+	internal bool
+}
+
+func TestDump_struct(t *testing.T) {
+	node := testDumpNode{
+		Name:     "root",
+		Level:    2,
+		Tags:     []string{"a", "b"},
+		Values:   map[string]int{"z": 26, "a": 1},
+		internal: true,
+	}
+	got := DumpWith(&node, DumpOptions{SortMapKeys: true})
+	expected := `&bvmgo_reflect.testDumpNode{
+  Name: "root",
+  Level: bvmgo_reflect.testDumpLevel(2),
+  Tags: []string{
+    "a",
+    "b",
+  },
+  Values: map[string]int{
+    "a": 1,
+    "z": 26,
+  },
+  Next: (*bvmgo_reflect.testDumpNode)(nil),
+  internal: true,
+}
+`
+	if got != expected {
+		t.Errorf("DumpWith(...) = [%v], want [%v]", got, expected)
+	}
+}
+
+func TestDump_scalars(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  any
+		want string
+	}{
+		{name: "nil", arg: nil, want: "nil\n"},
+		{name: "int", arg: 12, want: "12\n"},
+		{name: "float", arg: 1.5, want: "1.5\n"},
+		{name: "complex", arg: complex(1, 2), want: "(1+2i)\n"},
+		{name: "duration", arg: time.Second, want: "time.Duration(1000000000)\n"},
+		{name: "time", arg: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), want: "time.Time(\"2024-01-02 00:00:00 +0000 UTC\")\n"},
+		{name: "nil slice", arg: []int(nil), want: "[]int(nil)\n"},
+		{name: "empty struct", arg: struct{}{}, want: "struct {}{}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Dump(tt.arg); got != tt.want {
+				t.Errorf("Dump() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDump_cycle(t *testing.T) {
+	node := &testDumpNode{Name: "loop"}
+	node.Next = node
+	got := Dump(node)
+	if !strings.Contains(got, "Next: (*bvmgo_reflect.testDumpNode)(0x") || !strings.Contains(got, "// cycle") {
+		t.Errorf("Dump(...) = [%v], want contain a cycle marker", got)
+	}
+}
+
+func TestDump_mapAndSliceCycles(t *testing.T) {
+	values := map[string]any{"name": "loop"}
+	values["self"] = values
+	got := Dump(values)
+	if !strings.Contains(got, `"self": (map[string]interface {})(0x`) || !strings.Contains(got, "// cycle") {
+		t.Errorf("Dump(map cycle) = [%v], want contain a cycle marker", got)
+	}
+	list := []any{"loop", nil}
+	list[1] = list
+	got = Dump(list)
+	if !strings.Contains(got, "([]interface {})(0x") || !strings.Contains(got, "// cycle") {
+		t.Errorf("Dump(slice cycle) = [%v], want contain a cycle marker", got)
+	}
+}
+
+func TestDump_shared(t *testing.T) {
+	shared := &testDumpNode{Name: "shared"}
+	got := Dump([]*testDumpNode{shared, shared})
+	if !strings.Contains(got, "&/* 0x") || !strings.Contains(got, "// shared") {
+		t.Errorf("Dump(...) = [%v], want contain shared reference markers", got)
+	}
+}
+
+func TestDumpWith_truncation(t *testing.T) {
+	node := testDumpNode{Name: "abcdef", Tags: []string{"a", "b", "c"}, Next: &testDumpNode{Name: "child"}}
+	tests := []struct {
+		options DumpOptions
+		want    string
+	}{
+		{options: DumpOptions{MaxLength: 2}, want: `"ab" /* ... 4 more */`},
+		{options: DumpOptions{MaxLength: 2}, want: "// ... 1 more"},
+		{options: DumpOptions{MaxDepth: 1}, want: "Next: &bvmgo_reflect.testDumpNode{...}"},
+	}
+	for _, tt := range tests {
+		if got := DumpWith(node, tt.options); !strings.Contains(got, tt.want) {
+			t.Errorf("DumpWith(...) = [%v], want contain [%v]", got, tt.want)
+		}
+	}
+}
+
+func TestFdump(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := Fdump(&buffer, []int{1}); err != nil {
+		t.Errorf("Fdump(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	expected := "[]int{\n  1,\n}\n"
+	if buffer.String() != expected {
+		t.Errorf("Fdump(...) = [%v], want [%v]", buffer.String(), expected)
+	}
+}
@@ -0,0 +1,366 @@
+package bvmgo_reflect
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EqualOption configures Equal and Diff functions.
+type EqualOption func(options *equalOptions)
+
+// equalOptions contains Equal and Diff functions options.
+type equalOptions struct {
+	ignoredPaths     []*regexp.Regexp
+	ignoredTags      map[string]string
+	equateEmpty      bool
+	epsilon          float64
+	ignoreUnexported bool
+	useEqualMethods  bool
+}
+
+// Mismatch is a difference between two values.
+type Mismatch struct {
+	// Path is the path of the different values ("Address.City", "Tags[2]", `Labels["key"]`), empty for roots.
+	Path string
+	// A is the rendering of the first value ("<missing>" if it does not exist).
+	A string
+	// B is the rendering of the second value ("<missing>" if it does not exist).
+	B string
+}
+
+// EqualReport lists the differences between two values.
+type EqualReport struct {
+	// Mismatches are the differences, in traversal order.
+	Mismatches []Mismatch
+}
+
+// missingValue is the rendering of a missing value (slice element, map entry).
+const missingValue = "<missing>"
+
+// IgnorePaths option ignores values at paths ("Address.City", "Tags[2]", `Labels["key"]`).
+// "*" matches any field name and "[*]" matches any index or key.
+func IgnorePaths(paths ...string) EqualOption {
+	return func(options *equalOptions) {
+		for _, path := range paths {
+			pattern := regexp.QuoteMeta(strings.TrimPrefix(strings.TrimSpace(path), "."))
+			pattern = strings.ReplaceAll(pattern, `\[\*\]`, `\[[^\]]*\]`)
+			pattern = strings.ReplaceAll(pattern, `\*`, `[^.\[]*`)
+			options.ignoredPaths = append(options.ignoredPaths, regexp.MustCompile("^"+pattern+"$"))
+		}
+	}
+}
+
+// IgnoreFieldsTagged option ignores structure fields whose tag key has value (for instance `equal:"-"`).
+func IgnoreFieldsTagged(key string, value string) EqualOption {
+	return func(options *equalOptions) {
+		if options.ignoredTags == nil {
+			options.ignoredTags = make(map[string]string)
+		}
+		options.ignoredTags[key] = value
+	}
+}
+
+// EquateEmpty option considers nil and empty slices (or maps) as equal.
+func EquateEmpty() EqualOption {
+	return func(options *equalOptions) {
+		options.equateEmpty = true
+	}
+}
+
+// FloatEpsilon option considers floats (and complex parts) as equal if their difference is at most epsilon.
+func FloatEpsilon(epsilon float64) EqualOption {
+	return func(options *equalOptions) {
+		options.epsilon = math.Abs(epsilon)
+	}
+}
+
+// IgnoreUnexported option ignores unexported structure fields.
+func IgnoreUnexported() EqualOption {
+	return func(options *equalOptions) {
+		options.ignoreUnexported = true
+	}
+}
+
+// UseEqualMethods option compares values having an "Equal(T) bool" method (like time.Time) with this method.
+func UseEqualMethods() EqualOption {
+	return func(options *equalOptions) {
+		options.useEqualMethods = true
+	}
+}
+
+// Equal function returns true if a and b are deeply equal (see reflect.DeepEqual) according to options.
+func Equal(a any, b any, options ...EqualOption) bool {
+	return Diff(a, b, options...).Equal()
+}
+
+// Diff function returns the differences between a and b according to options.
+func Diff(a any, b any, options ...EqualOption) EqualReport {
+	comparator := equalComparator{visited: make(map[visitedPair]bool)}
+	for _, option := range options {
+		option(&comparator.options)
+	}
+	comparator.compare("", reflect.ValueOf(a), reflect.ValueOf(b))
+	return comparator.report
+}
+
+// Equal function returns true if the report contains no mismatch.
+func (report EqualReport) Equal() bool {
+	return len(report.Mismatches) == 0
+}
+
+// String function returns the report as text, a mismatch per line.
+func (report EqualReport) String() string {
+	var builder strings.Builder
+	for index, mismatch := range report.Mismatches {
+		if index > 0 {
+			builder.WriteString("\n")
+		}
+		path := mismatch.Path
+		if len(path) == 0 {
+			path = "(root)"
+		}
+		fmt.Fprintf(&builder, "%s: %s != %s", path, mismatch.A, mismatch.B)
+	}
+	return builder.String()
+}
+
+// visitedPair is a pair of compared pointers (cycles detection).
+type visitedPair struct {
+	a, b      uintptr
+	valueType reflect.Type
+}
+
+// equalComparator compares values and records mismatches.
+type equalComparator struct {
+	options equalOptions
+	report  EqualReport
+	visited map[visitedPair]bool
+}
+
+// mismatch function records a mismatch.
+func (comparator *equalComparator) mismatch(path string, a string, b string) {
+	comparator.report.Mismatches = append(comparator.report.Mismatches, Mismatch{Path: path, A: a, B: b})
+}
+
+// isIgnoredPath function returns true if path is ignored by options.
+func (comparator *equalComparator) isIgnoredPath(path string) bool {
+	for _, pattern := range comparator.options.ignoredPaths {
+		if pattern.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderValue function returns the rendering of a value in a mismatch.
+func renderValue(value reflect.Value) string {
+	if !value.IsValid() {
+		return "nil"
+	}
+	if value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Kind() == reflect.Struct {
+		return "&" + renderValue(value.Elem())
+	}
+	return fmt.Sprintf("%#v", value)
+}
+
+// compare function compares two values at path.
+func (comparator *equalComparator) compare(path string, a reflect.Value, b reflect.Value) {
+	if comparator.isIgnoredPath(path) {
+		return
+	}
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			comparator.mismatch(path, renderValue(a), renderValue(b))
+		}
+		return
+	}
+	if a.Type() != b.Type() {
+		comparator.mismatch(path, renderValue(a)+" ("+typeName(a.Type())+")", renderValue(b)+" ("+typeName(b.Type())+")")
+		return
+	}
+	if comparator.options.useEqualMethods && comparator.compareWithEqualMethod(path, a, b) {
+		return
+	}
+	switch a.Kind() {
+	case reflect.Interface:
+		comparator.compare(path, a.Elem(), b.Elem())
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				comparator.mismatch(path, renderValue(a), renderValue(b))
+			}
+			return
+		}
+		if a.Pointer() == b.Pointer() || comparator.isVisited(a, b) {
+			return
+		}
+		comparator.compare(path, a.Elem(), b.Elem())
+	case reflect.Struct:
+		structType := a.Type()
+		for index := 0; index < structType.NumField(); index++ {
+			field := structType.Field(index)
+			if comparator.options.ignoreUnexported && !field.IsExported() {
+				continue
+			}
+			if comparator.isIgnoredField(field) {
+				continue
+			}
+			fieldPath := field.Name
+			if len(path) > 0 {
+				fieldPath = path + "." + field.Name
+			}
+			comparator.compare(fieldPath, a.Field(index), b.Field(index))
+		}
+	case reflect.Slice, reflect.Array:
+		if a.Kind() == reflect.Slice {
+			if a.IsNil() != b.IsNil() && !(comparator.options.equateEmpty && a.Len() == 0 && b.Len() == 0) {
+				comparator.mismatch(path, renderValue(a), renderValue(b))
+				return
+			}
+			if a.Pointer() == b.Pointer() && a.Len() == b.Len() {
+				return
+			}
+			if a.Len() > 0 && comparator.isVisited(a, b) {
+				return
+			}
+		}
+		for index := 0; index < a.Len() || index < b.Len(); index++ {
+			elementPath := path + "[" + strconv.Itoa(index) + "]"
+			switch {
+			case index >= a.Len():
+				if !comparator.isIgnoredPath(elementPath) {
+					comparator.mismatch(elementPath, missingValue, renderValue(b.Index(index)))
+				}
+			case index >= b.Len():
+				if !comparator.isIgnoredPath(elementPath) {
+					comparator.mismatch(elementPath, renderValue(a.Index(index)), missingValue)
+				}
+			default:
+				comparator.compare(elementPath, a.Index(index), b.Index(index))
+			}
+		}
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() && !(comparator.options.equateEmpty && a.Len() == 0 && b.Len() == 0) {
+			comparator.mismatch(path, renderValue(a), renderValue(b))
+			return
+		}
+		if a.Pointer() == b.Pointer() || (a.Len() > 0 && comparator.isVisited(a, b)) {
+			return
+		}
+		keys := a.MapKeys()
+		for _, key := range b.MapKeys() {
+			if !a.MapIndex(key).IsValid() {
+				keys = append(keys, key)
+			}
+		}
+		sortValues(keys)
+		for _, key := range keys {
+			entryPath := path + "[" + fmt.Sprintf("%#v", key) + "]"
+			aEntry, bEntry := a.MapIndex(key), b.MapIndex(key)
+			switch {
+			case !aEntry.IsValid():
+				if !comparator.isIgnoredPath(entryPath) {
+					comparator.mismatch(entryPath, missingValue, renderValue(bEntry))
+				}
+			case !bEntry.IsValid():
+				if !comparator.isIgnoredPath(entryPath) {
+					comparator.mismatch(entryPath, renderValue(aEntry), missingValue)
+				}
+			default:
+				comparator.compare(entryPath, aEntry, bEntry)
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		if !comparator.equalFloats(a.Float(), b.Float()) {
+			comparator.mismatch(path, renderValue(a), renderValue(b))
+		}
+	case reflect.Complex64, reflect.Complex128:
+		if !comparator.equalFloats(real(a.Complex()), real(b.Complex())) ||
+			!comparator.equalFloats(imag(a.Complex()), imag(b.Complex())) {
+			comparator.mismatch(path, renderValue(a), renderValue(b))
+		}
+	case reflect.Func:
+		// Functions are equal only if both are nil (see reflect.DeepEqual)
+		if !a.IsNil() || !b.IsNil() {
+			comparator.mismatch(path, renderValue(a), renderValue(b))
+		}
+	case reflect.Chan, reflect.UnsafePointer:
+		if a.Pointer() != b.Pointer() {
+			comparator.mismatch(path, renderValue(a), renderValue(b))
+		}
+	default:
+		if !comparator.equalScalars(a, b) {
+			comparator.mismatch(path, renderValue(a), renderValue(b))
+		}
+	}
+}
+
+// isVisited function returns true if a and b are already being compared (cycle), and marks them as visited.
+func (comparator *equalComparator) isVisited(a reflect.Value, b reflect.Value) bool {
+	pair := visitedPair{a: a.Pointer(), b: b.Pointer(), valueType: a.Type()}
+	if comparator.visited[pair] {
+		return true
+	}
+	comparator.visited[pair] = true
+	return false
+}
+
+// isIgnoredField function returns true if field is ignored by its tag.
+func (comparator *equalComparator) isIgnoredField(field reflect.StructField) bool {
+	for key, value := range comparator.options.ignoredTags {
+		if tagValue, ok := field.Tag.Lookup(key); ok && tagValue == value {
+			return true
+		}
+	}
+	return false
+}
+
+// equalFloats function returns true if floats are equal (within epsilon option).
+func (comparator *equalComparator) equalFloats(a float64, b float64) bool {
+	return a == b || math.Abs(a-b) <= comparator.options.epsilon
+}
+
+// equalScalars function returns true if scalar values (bool, integers, strings) are equal.
+func (comparator *equalComparator) equalScalars(a reflect.Value, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.String:
+		return a.String() == b.String()
+	default:
+		return false
+	}
+}
+
+// compareWithEqualMethod function compares values with their "Equal(T) bool" method.
+//
+// compareWithEqualMethod function returns false if values have no such method.
+func (comparator *equalComparator) compareWithEqualMethod(path string, a reflect.Value, b reflect.Value) bool {
+	if !a.CanInterface() || !b.CanInterface() {
+		return false
+	}
+	if a.Kind() == reflect.Ptr && (a.IsNil() || b.IsNil()) {
+		return false
+	}
+	method := a.MethodByName("Equal")
+	if !method.IsValid() {
+		return false
+	}
+	methodType := method.Type()
+	if methodType.NumIn() != 1 || methodType.NumOut() != 1 || methodType.Out(0).Kind() != reflect.Bool ||
+		!a.Type().AssignableTo(methodType.In(0)) {
+		return false
+	}
+	if !method.Call([]reflect.Value{b})[0].Bool() {
+		comparator.mismatch(path, renderValue(a), renderValue(b))
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package bvmgo_reflect
+
+import (
+	"testing"
+	"time"
+)
+
+type testEqualAddress struct {
+	City string
+	Zip  string `equal:"-"`
+}
+
+type testEqualPerson struct {
+	Name     string
+	Score    float64
+	Tags     []string
+	Labels   map[string]int
+	Address  *testEqualAddress
+	Birthday time.Time
+	internal int
+}
+
+type testEqualNode struct {
+	Value int
+	Next  *testEqualNode
+}
+
+func TestEqual(t *testing.T) {
+	birthday := time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)
+	base := testEqualPerson{Name: "alice", Score: 1.5, Tags: []string{"a"}, Labels: map[string]int{"x": 1},
+		Address: &testEqualAddress{City: "Paris", Zip: "75001"}, Birthday: birthday, internal: 1}
+	tests := []struct {
+		name    string
+		modify  func(person *testEqualPerson)
+		options []EqualOption
+		want    bool
+	}{
+		{"Same", func(person *testEqualPerson) {}, nil, true},
+		{"Different name", func(person *testEqualPerson) { person.Name = "bob" }, nil, false},
+		{"Ignored path", func(person *testEqualPerson) { person.Address = &testEqualAddress{City: "Lyon", Zip: "75001"} },
+			[]EqualOption{IgnorePaths("Address.City")}, true},
+		{"Ignored wildcard index", func(person *testEqualPerson) { person.Tags = []string{"b"} },
+			[]EqualOption{IgnorePaths("Tags[*]")}, true},
+		{"Ignored tag", func(person *testEqualPerson) { person.Address = &testEqualAddress{City: "Paris", Zip: "69000"} },
+			[]EqualOption{IgnoreFieldsTagged("equal", "-")}, true},
+		{"Not ignored tag", func(person *testEqualPerson) { person.Address = &testEqualAddress{City: "Paris", Zip: "69000"} },
+			nil, false},
+		{"Empty slice", func(person *testEqualPerson) { person.Tags = nil },
+			nil, false},
+		{"Float epsilon", func(person *testEqualPerson) { person.Score = 1.5000001 },
+			[]EqualOption{FloatEpsilon(1e-6)}, true},
+		{"Float without epsilon", func(person *testEqualPerson) { person.Score = 1.5000001 }, nil, false},
+		{"Ignored unexported", func(person *testEqualPerson) { person.internal = 2 },
+			[]EqualOption{IgnoreUnexported()}, true},
+		{"Unexported", func(person *testEqualPerson) { person.internal = 2 }, nil, false},
+		{"Equal method", func(person *testEqualPerson) { person.Birthday = birthday.In(time.FixedZone("UTC+1", 3600)) },
+			[]EqualOption{UseEqualMethods()}, true},
+		{"Without equal method", func(person *testEqualPerson) { person.Birthday = birthday.In(time.FixedZone("UTC+1", 3600)) },
+			nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := base
+			other.Address = &testEqualAddress{City: "Paris", Zip: "75001"}
+			tt.modify(&other)
+			if got := Equal(base, other, tt.options...); got != tt.want {
+				t.Errorf("Equal(...) = %v, want %v (%s)", got, tt.want, Diff(base, other, tt.options...))
+			}
+		})
+	}
+}
+
+func TestEqual_equateEmpty(t *testing.T) {
+	a := testEqualPerson{Tags: []string{}, Labels: map[string]int{}}
+	b := testEqualPerson{}
+	if Equal(a, b) {
+		t.Errorf("Equal(...) = true, want false")
+	}
+	if !Equal(a, b, EquateEmpty()) {
+		t.Errorf("Equal(..., EquateEmpty()) = false, want true")
+	}
+}
+
+func TestEqual_cycle(t *testing.T) {
+	a := &testEqualNode{Value: 1}
+	a.Next = &testEqualNode{Value: 2, Next: a}
+	b := &testEqualNode{Value: 1}
+	b.Next = &testEqualNode{Value: 2, Next: b}
+	if !Equal(a, b) {
+		t.Errorf("Equal(...) = false, want true")
+	}
+	b.Next.Value = 3
+	if Equal(a, b) {
+		t.Errorf("Equal(...) = true, want false")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := testEqualPerson{Name: "alice", Tags: []string{"a", "b"}, Labels: map[string]int{"x": 1, "y": 2},
+		Address: &testEqualAddress{City: "Paris"}}
+	b := testEqualPerson{Name: "bob", Tags: []string{"a"}, Labels: map[string]int{"x": 3, "z": 4},
+		Address: &testEqualAddress{City: "Lyon"}}
+	expected := []Mismatch{
+		{Path: "Name", A: `"alice"`, B: `"bob"`},
+		{Path: "Tags[1]", A: `"b"`, B: missingValue},
+		{Path: `Labels["x"]`, A: "1", B: "3"},
+		{Path: `Labels["y"]`, A: "2", B: missingValue},
+		{Path: `Labels["z"]`, A: missingValue, B: "4"},
+		{Path: "Address.City", A: `"Paris"`, B: `"Lyon"`},
+	}
+	report := Diff(a, b)
+	if len(report.Mismatches) != len(expected) {
+		t.Errorf("Diff(...) = \n%s\nwant %d mismatches", report, len(expected))
+		return
+	}
+	for index, mismatch := range report.Mismatches {
+		if mismatch != expected[index] {
+			t.Errorf("Diff(...) mismatch [%d] = %+v, want %+v", index, mismatch, expected[index])
+		}
+	}
+	if got, want := Diff(1, "1").String(), `(root): 1 (int) != "1" (string)`; got != want {
+		t.Errorf("Diff(1, \"1\").String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,1173 @@
+package bvmgo_reflect
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Expression is an expression compiled against a root type, evaluated with Evaluate function.
+//
+// Expression syntax is a subset of Go expressions:
+//   - literals: integers (decimal, or prefixed by 0x, 0o or 0b), floats, strings ("text" or `text`), true, false and nil,
+//   - field paths (Address.City) and method calls (Address.Format(", ")) on root value,
+//   - indexing of slices, arrays, strings and maps (Tags[0], Labels["env"]),
+//   - arithmetic operators: +, -, *, / and % (+ also concatenates strings),
+//   - comparison operators: ==, !=, <, <=, > and >=,
+//   - boolean operators: &&, || and !,
+//   - functions: len(value), lower(text), upper(text), trim(text), contains(text or slice, element),
+//     hasPrefix(text, prefix) and hasSuffix(text, suffix).
+//
+// Integer operations are computed with int64 values and float operations with float64 values.
+// Pointers are dereferenced automatically, evaluating a nil pointer operand returns an error
+// (except in comparisons with nil).
+type Expression struct {
+	source    string
+	rootType  reflect.Type
+	root      *expressionNode
+	valueType reflect.Type
+}
+
+// Compile function returns the expression compiled against rootType (pointers are dereferenced).
+//
+// Compile function returns an error if:
+//   - source is not a valid expression,
+//   - a field, a method or a function is not found,
+//   - an operator, an index or an argument type is invalid for its operands.
+func Compile(source string, rootType reflect.Type) (*Expression, error) {
+	if rootType == nil {
+		return nil, fmt.Errorf("a root type is required to compile [%s] expression", source)
+	}
+	rootType = derefType(rootType)
+	tokens, err := tokenizeExpression(source)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] expression cannot be compiled: %w", source, err)
+	}
+	parser := expressionParser{source: source, tokens: tokens, rootType: rootType}
+	root, err := parser.parseExpression()
+	if err == nil && parser.peek().kind != tokenEnd {
+		err = parser.errorf("unexpected [%s]", parser.peek().text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[%s] expression cannot be compiled: %w", source, err)
+	}
+	if root.valueType == nil {
+		return nil, fmt.Errorf("[%s] expression cannot be compiled: expression type is nil", source)
+	}
+	return &Expression{source: source, rootType: rootType, root: root, valueType: root.valueType}, nil
+}
+
+// CompileOf function returns the expression compiled against T type.
+//
+// See Compile function.
+func CompileOf[T any](source string) (*Expression, error) {
+	return Compile(source, reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// String function returns the source of the expression.
+func (expression *Expression) String() string {
+	return expression.source
+}
+
+// Type function returns the type of the values returned by Evaluate function.
+func (expression *Expression) Type() reflect.Type {
+	return expression.valueType
+}
+
+// Evaluate function returns the value of the expression for root (a value or a pointer of root type).
+//
+// Evaluate function returns an error if:
+//   - root type is not the root type of the expression,
+//   - a nil pointer is dereferenced, an index is out of range or an integer is divided by zero,
+//   - a method panics or returns an error.
+func (expression *Expression) Evaluate(root any) (result any, err error) {
+	rootValue := reflect.ValueOf(root)
+	for rootValue.Kind() == reflect.Ptr && rootValue.Type() != expression.rootType {
+		if rootValue.IsNil() {
+			return nil, fmt.Errorf("a not nil pointer is required to evaluate [%s] expression", expression.source)
+		}
+		rootValue = rootValue.Elem()
+	}
+	if !rootValue.IsValid() || rootValue.Type() != expression.rootType {
+		return nil, fmt.Errorf("unsupported type [%s], [%s] type is required to evaluate [%s] expression",
+			TypeName(root), typeName(expression.rootType), expression.source)
+	}
+	// Addressable root value: methods with pointer receiver can be called
+	if !rootValue.CanAddr() {
+		addressable := reflect.New(expression.rootType).Elem()
+		addressable.Set(rootValue)
+		rootValue = addressable
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("[%s] expression cannot be evaluated: %v", expression.source, recovered)
+		}
+	}()
+	value, err := expression.root.evaluate(rootValue)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] expression cannot be evaluated: %w", expression.source, err)
+	}
+	return value.Interface(), nil
+}
+
+// EvaluateBool function returns the value of a boolean expression for root (filters, rules).
+//
+// See Evaluate function, EvaluateBool function also returns an error if the expression type is not a boolean.
+func (expression *Expression) EvaluateBool(root any) (bool, error) {
+	if expression.valueType.Kind() != reflect.Bool {
+		return false, fmt.Errorf("[%s] expression type is [%s], a boolean is required",
+			expression.source, typeName(expression.valueType))
+	}
+	result, err := expression.Evaluate(root)
+	if err != nil {
+		return false, err
+	}
+	return reflect.ValueOf(result).Bool(), nil
+}
+
+// Expression types
+var (
+	int64Type   = reflect.TypeOf(int64(0))
+	float64Type = reflect.TypeOf(float64(0))
+	stringType  = reflect.TypeOf("")
+	boolType    = reflect.TypeOf(false)
+	intType     = reflect.TypeOf(0)
+)
+
+// expressionNode is a compiled sub-expression.
+type expressionNode struct {
+	// text is the source of the sub-expression (errors)
+	text string
+	// valueType is the static type of the sub-expression (nil for nil literal)
+	valueType reflect.Type
+	evaluate  func(root reflect.Value) (reflect.Value, error)
+}
+
+// tokenKind is the kind of an expression token.
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenIdentifier
+	tokenInteger
+	tokenFloat
+	tokenString
+	tokenOperator
+)
+
+// expressionToken is a token of an expression source.
+type expressionToken struct {
+	kind     tokenKind
+	text     string
+	position int
+}
+
+// expressionOperators are the operators and punctuations of expressions, longest first.
+var expressionOperators = []string{"==", "!=", "<=", ">=", "&&", "||",
+	"<", ">", "!", "+", "-", "*", "/", "%", "(", ")", "[", "]", ".", ","}
+
+// tokenizeExpression function returns the tokens of an expression source.
+func tokenizeExpression(source string) ([]expressionToken, error) {
+	var tokens []expressionToken
+	position := 0
+	for position < len(source) {
+		character, size := utf8.DecodeRuneInString(source[position:])
+		start := position
+		switch {
+		case unicode.IsSpace(character):
+			position += size
+			continue
+		case unicode.IsLetter(character) || character == '_':
+			for position < len(source) {
+				character, size = utf8.DecodeRuneInString(source[position:])
+				if !unicode.IsLetter(character) && !unicode.IsDigit(character) && character != '_' {
+					break
+				}
+				position += size
+			}
+			tokens = append(tokens, expressionToken{kind: tokenIdentifier, text: source[start:position], position: start})
+		case character == '0' && position+1 < len(source) && strings.ContainsRune("xXoObB", rune(source[position+1])):
+			// Hexadecimal, octal or binary integer (digits are checked by the parser)
+			position += 2
+			for position < len(source) && (isDigit(source[position]) || unicode.IsLetter(rune(source[position])) ||
+				source[position] == '_') {
+				position++
+			}
+			tokens = append(tokens, expressionToken{kind: tokenInteger, text: source[start:position], position: start})
+		case unicode.IsDigit(character):
+			kind := tokenInteger
+			for position < len(source) && (isDigit(source[position]) || source[position] == '.' || source[position] == '_') {
+				if source[position] == '.' {
+					kind = tokenFloat
+				}
+				position++
+			}
+			if position < len(source) && (source[position] == 'e' || source[position] == 'E') {
+				kind = tokenFloat
+				position++
+				if position < len(source) && (source[position] == '+' || source[position] == '-') {
+					position++
+				}
+				for position < len(source) && isDigit(source[position]) {
+					position++
+				}
+			}
+			tokens = append(tokens, expressionToken{kind: kind, text: source[start:position], position: start})
+		case character == '"' || character == '`':
+			position++
+			for position < len(source) && rune(source[position]) != character {
+				if source[position] == '\\' && character == '"' {
+					position++
+				}
+				position++
+			}
+			if position >= len(source) {
+				return nil, fmt.Errorf("position %d: string is not terminated", start)
+			}
+			position++
+			tokens = append(tokens, expressionToken{kind: tokenString, text: source[start:position], position: start})
+		default:
+			operator := ""
+			for _, candidate := range expressionOperators {
+				if strings.HasPrefix(source[position:], candidate) {
+					operator = candidate
+					break
+				}
+			}
+			if len(operator) == 0 {
+				return nil, fmt.Errorf("position %d: unexpected [%c] character", start, character)
+			}
+			position += len(operator)
+			tokens = append(tokens, expressionToken{kind: tokenOperator, text: operator, position: start})
+		}
+	}
+	return append(tokens, expressionToken{kind: tokenEnd, text: "end of expression", position: len(source)}), nil
+}
+
+// isDigit function returns true if character is an ASCII digit.
+func isDigit(character byte) bool {
+	return character >= '0' && character <= '9'
+}
+
+// expressionParser parses and type checks expression tokens (recursive descent parser).
+type expressionParser struct {
+	source   string
+	tokens   []expressionToken
+	index    int
+	rootType reflect.Type
+}
+
+// peek function returns the current token.
+func (parser *expressionParser) peek() expressionToken {
+	return parser.tokens[parser.index]
+}
+
+// next function returns the current token and moves to the next one.
+func (parser *expressionParser) next() expressionToken {
+	token := parser.tokens[parser.index]
+	if token.kind != tokenEnd {
+		parser.index++
+	}
+	return token
+}
+
+// accept function moves to the next token if the current token is operator.
+func (parser *expressionParser) accept(operator string) bool {
+	if token := parser.peek(); token.kind == tokenOperator && token.text == operator {
+		parser.index++
+		return true
+	}
+	return false
+}
+
+// expect function moves to the next token if the current token is operator, or returns an error.
+func (parser *expressionParser) expect(operator string) error {
+	if !parser.accept(operator) {
+		return parser.errorf("[%s] expected, [%s] found", operator, parser.peek().text)
+	}
+	return nil
+}
+
+// errorf function returns an error at the current token position.
+func (parser *expressionParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("position %d: %s", parser.peek().position, fmt.Sprintf(format, args...))
+}
+
+// textFrom function returns the source from start token to the previous token.
+func (parser *expressionParser) textFrom(start int) string {
+	end := len(parser.source)
+	if parser.index < len(parser.tokens) {
+		end = parser.tokens[parser.index].position
+	}
+	return strings.TrimSpace(parser.source[parser.tokens[start].position:end])
+}
+
+// parseExpression function parses an "||" expression.
+func (parser *expressionParser) parseExpression() (*expressionNode, error) {
+	return parser.parseLogical("||", parser.parseAnd)
+}
+
+// parseAnd function parses an "&&" expression.
+func (parser *expressionParser) parseAnd() (*expressionNode, error) {
+	return parser.parseLogical("&&", parser.parseComparison)
+}
+
+// parseLogical function parses a sequence of operands separated by a boolean operator.
+func (parser *expressionParser) parseLogical(operator string, parseOperand func() (*expressionNode, error)) (*expressionNode, error) {
+	start := parser.index
+	left, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for parser.accept(operator) {
+		right, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		left, right = derefOperand(left), derefOperand(right)
+		if !isKind(left.valueType, reflect.Bool) || !isKind(right.valueType, reflect.Bool) {
+			return nil, operatorError(operator, left.valueType, right.valueType)
+		}
+		left = logicalNode(parser.textFrom(start), operator, left, right)
+	}
+	return left, nil
+}
+
+// parseComparison function parses a comparison expression.
+func (parser *expressionParser) parseComparison() (*expressionNode, error) {
+	start := parser.index
+	left, err := parser.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	token := parser.peek()
+	if token.kind != tokenOperator {
+		return left, nil
+	}
+	switch token.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+		parser.next()
+	default:
+		return left, nil
+	}
+	right, err := parser.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	return comparisonNode(parser.textFrom(start), token.text, left, right)
+}
+
+// parseAdditive function parses a "+" or "-" expression.
+func (parser *expressionParser) parseAdditive() (*expressionNode, error) {
+	return parser.parseArithmetic([]string{"+", "-"}, parser.parseMultiplicative)
+}
+
+// parseMultiplicative function parses a "*", "/" or "%" expression.
+func (parser *expressionParser) parseMultiplicative() (*expressionNode, error) {
+	return parser.parseArithmetic([]string{"*", "/", "%"}, parser.parseUnary)
+}
+
+// parseArithmetic function parses a sequence of operands separated by arithmetic operators.
+func (parser *expressionParser) parseArithmetic(operators []string, parseOperand func() (*expressionNode, error)) (*expressionNode, error) {
+	start := parser.index
+	left, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		token := parser.peek()
+		if token.kind != tokenOperator || !slices.Contains(operators, token.text) {
+			return left, nil
+		}
+		parser.next()
+		right, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if left, err = arithmeticNode(parser.textFrom(start), token.text, left, right); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseUnary function parses a "!" or "-" expression.
+func (parser *expressionParser) parseUnary() (*expressionNode, error) {
+	start := parser.index
+	if parser.accept("!") {
+		operand, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		operand = derefOperand(operand)
+		if !isKind(operand.valueType, reflect.Bool) {
+			return nil, fmt.Errorf("[!] operator is not defined on [%s] type", expressionTypeName(operand.valueType))
+		}
+		return &expressionNode{text: parser.textFrom(start), valueType: boolType,
+			evaluate: func(root reflect.Value) (reflect.Value, error) {
+				value, err := operand.evaluate(root)
+				if err != nil {
+					return value, err
+				}
+				return reflect.ValueOf(!value.Bool()), nil
+			}}, nil
+	}
+	if parser.accept("-") {
+		operand, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		zero := constantNode("0", reflect.ValueOf(int64(0)))
+		return arithmeticNode(parser.textFrom(start), "-", zero, operand)
+	}
+	return parser.parsePostfix()
+}
+
+// parsePostfix function parses field accesses, method calls and indexing following a primary expression.
+func (parser *expressionParser) parsePostfix() (*expressionNode, error) {
+	start := parser.index
+	node, err := parser.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case parser.accept("."):
+			name := parser.next()
+			if name.kind != tokenIdentifier {
+				parser.index--
+				return nil, parser.errorf("field or method name expected, [%s] found", name.text)
+			}
+			if parser.accept("(") {
+				var arguments []*expressionNode
+				if arguments, err = parser.parseArguments(); err != nil {
+					return nil, err
+				}
+				node, err = methodNode(parser.textFrom(start), node, name.text, arguments)
+			} else {
+				node, err = fieldNode(parser.textFrom(start), node, name.text)
+			}
+		case parser.accept("["):
+			var index *expressionNode
+			if index, err = parser.parseExpression(); err != nil {
+				return nil, err
+			}
+			if err = parser.expect("]"); err != nil {
+				return nil, err
+			}
+			node, err = indexNode(parser.textFrom(start), node, index)
+		default:
+			return node, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseArguments function parses call arguments, after the opening parenthesis.
+func (parser *expressionParser) parseArguments() ([]*expressionNode, error) {
+	var arguments []*expressionNode
+	if parser.accept(")") {
+		return arguments, nil
+	}
+	for {
+		argument, err := parser.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+		if parser.accept(")") {
+			return arguments, nil
+		}
+		if err = parser.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parsePrimary function parses a literal, a root field, a root method call, a function call
+// or a parenthesized expression.
+func (parser *expressionParser) parsePrimary() (*expressionNode, error) {
+	start := parser.index
+	token := parser.next()
+	switch token.kind {
+	case tokenInteger:
+		if len(token.text) > 1 && token.text[0] == '0' && (isDigit(token.text[1]) || token.text[1] == '_') {
+			parser.index--
+			return nil, parser.errorf("invalid [%s] integer, leading zeros are not allowed (use 0o prefix for octal)", token.text)
+		}
+		number, err := strconv.ParseInt(token.text, 0, 64)
+		if err != nil {
+			parser.index--
+			return nil, parser.errorf("invalid [%s] integer", token.text)
+		}
+		return constantNode(token.text, reflect.ValueOf(number)), nil
+	case tokenFloat:
+		number, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			parser.index--
+			return nil, parser.errorf("invalid [%s] float", token.text)
+		}
+		return constantNode(token.text, reflect.ValueOf(number)), nil
+	case tokenString:
+		text, err := strconv.Unquote(token.text)
+		if err != nil {
+			parser.index--
+			return nil, parser.errorf("invalid %s string", token.text)
+		}
+		return constantNode(token.text, reflect.ValueOf(text)), nil
+	case tokenIdentifier:
+		switch token.text {
+		case "true", "false":
+			return constantNode(token.text, reflect.ValueOf(token.text == "true")), nil
+		case "nil":
+			return &expressionNode{text: token.text, evaluate: func(root reflect.Value) (reflect.Value, error) {
+				return reflect.Value{}, nil
+			}}, nil
+		}
+		root := &expressionNode{valueType: parser.rootType, evaluate: func(root reflect.Value) (reflect.Value, error) {
+			return root, nil
+		}}
+		if parser.accept("(") {
+			arguments, err := parser.parseArguments()
+			if err != nil {
+				return nil, err
+			}
+			if function, found := expressionFunctions[token.text]; found {
+				return function(parser.textFrom(start), arguments)
+			}
+			return methodNode(parser.textFrom(start), root, token.text, arguments)
+		}
+		return fieldNode(token.text, root, token.text)
+	case tokenOperator:
+		if token.text == "(" {
+			node, err := parser.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			return node, parser.expect(")")
+		}
+	}
+	if token.kind != tokenEnd {
+		parser.index--
+	}
+	return nil, parser.errorf("unexpected [%s]", token.text)
+}
+
+// constantNode function returns a literal node.
+func constantNode(text string, value reflect.Value) *expressionNode {
+	return &expressionNode{text: text, valueType: value.Type(), evaluate: func(root reflect.Value) (reflect.Value, error) {
+		return value, nil
+	}}
+}
+
+// derefType function returns the type pointed by pointer types (recursively).
+func derefType(valueType reflect.Type) reflect.Type {
+	for valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+	return valueType
+}
+
+// derefValue function returns the value pointed by pointer values (recursively).
+func derefValue(value reflect.Value, text string) (reflect.Value, error) {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return value, fmt.Errorf("[%s] value is nil", text)
+		}
+		value = value.Elem()
+	}
+	return value, nil
+}
+
+// derefOperand function returns a node evaluating the value pointed by node if its type is a pointer
+// to a number, a string or a boolean (evaluation returns an error if the pointer is nil), else node itself.
+func derefOperand(node *expressionNode) *expressionNode {
+	if !isKind(node.valueType, reflect.Ptr) {
+		return node
+	}
+	valueType := derefType(node.valueType)
+	if !isNumberType(valueType) && valueType.Kind() != reflect.String && valueType.Kind() != reflect.Bool {
+		return node
+	}
+	return &expressionNode{text: node.text, valueType: valueType, evaluate: func(root reflect.Value) (reflect.Value, error) {
+		value, err := node.evaluate(root)
+		if err != nil {
+			return value, err
+		}
+		return derefValue(value, node.text)
+	}}
+}
+
+// expressionTypeName function returns the name of an expression type ("nil" for nil literal).
+func expressionTypeName(valueType reflect.Type) string {
+	if valueType == nil {
+		return "nil"
+	}
+	return typeName(valueType)
+}
+
+// isKind function returns true if valueType is not nil and has kind.
+func isKind(valueType reflect.Type, kind reflect.Kind) bool {
+	return valueType != nil && valueType.Kind() == kind
+}
+
+// isIntegerType function returns true if valueType is a signed or unsigned integer type.
+func isIntegerType(valueType reflect.Type) bool {
+	if valueType == nil {
+		return false
+	}
+	switch valueType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+// isNumberType function returns true if valueType is an integer or a float type.
+func isNumberType(valueType reflect.Type) bool {
+	return isIntegerType(valueType) || isKind(valueType, reflect.Float32) || isKind(valueType, reflect.Float64)
+}
+
+// isNillableType function returns true if the values of valueType can be nil.
+func isNillableType(valueType reflect.Type) bool {
+	switch valueType.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Func, reflect.Chan:
+		return true
+	default:
+		return false
+	}
+}
+
+// toInt64 function returns the int64 value of an integer value.
+func toInt64(value reflect.Value) int64 {
+	if value.CanInt() {
+		return value.Int()
+	}
+	return int64(value.Uint())
+}
+
+// toFloat64 function returns the float64 value of an integer or float value.
+func toFloat64(value reflect.Value) float64 {
+	if value.CanFloat() {
+		return value.Float()
+	}
+	if value.CanInt() {
+		return float64(value.Int())
+	}
+	return float64(value.Uint())
+}
+
+// isConvertible function returns true if values of "from" type can be used as "to" type
+// (nil to nillable types, assignable types, numbers to numbers, strings to strings and booleans to booleans).
+func isConvertible(from reflect.Type, to reflect.Type) bool {
+	if from == nil {
+		return isNillableType(to)
+	}
+	return from.AssignableTo(to) ||
+		(isNumberType(from) && isNumberType(to)) ||
+		(from.Kind() == reflect.String && to.Kind() == reflect.String) ||
+		(from.Kind() == reflect.Bool && to.Kind() == reflect.Bool)
+}
+
+// convertValue function returns value converted to valueType (see isConvertible function).
+func convertValue(value reflect.Value, valueType reflect.Type) reflect.Value {
+	if !value.IsValid() {
+		return reflect.Zero(valueType)
+	}
+	if value.Type().AssignableTo(valueType) {
+		return value
+	}
+	return value.Convert(valueType)
+}
+
+// operatorError function returns the error of an operator not defined on its operand types.
+func operatorError(operator string, leftType reflect.Type, rightType reflect.Type) error {
+	return fmt.Errorf("[%s] operator is not defined on [%s] and [%s] types",
+		operator, expressionTypeName(leftType), expressionTypeName(rightType))
+}
+
+// logicalNode function returns a "&&" or "||" node, right operand is evaluated only if needed.
+func logicalNode(text string, operator string, left *expressionNode, right *expressionNode) *expressionNode {
+	return &expressionNode{text: text, valueType: boolType, evaluate: func(root reflect.Value) (reflect.Value, error) {
+		leftValue, err := left.evaluate(root)
+		if err != nil {
+			return leftValue, err
+		}
+		if leftValue.Bool() == (operator == "||") {
+			return reflect.ValueOf(leftValue.Bool()), nil
+		}
+		rightValue, err := right.evaluate(root)
+		if err != nil {
+			return rightValue, err
+		}
+		return reflect.ValueOf(rightValue.Bool()), nil
+	}}
+}
+
+// evaluateOperands function evaluates left and right operands.
+func evaluateOperands(root reflect.Value, left *expressionNode, right *expressionNode) (leftValue reflect.Value, rightValue reflect.Value, err error) {
+	if leftValue, err = left.evaluate(root); err != nil {
+		return
+	}
+	rightValue, err = right.evaluate(root)
+	return
+}
+
+// comparisonNode function returns a comparison node.
+func comparisonNode(text string, operator string, left *expressionNode, right *expressionNode) (*expressionNode, error) {
+	if left.valueType != nil && right.valueType != nil {
+		// Pointers are compared with nil, else their values are compared
+		left, right = derefOperand(left), derefOperand(right)
+	}
+	compare, err := compileComparison(operator, left.valueType, right.valueType)
+	if err != nil {
+		return nil, err
+	}
+	return &expressionNode{text: text, valueType: boolType, evaluate: func(root reflect.Value) (reflect.Value, error) {
+		leftValue, rightValue, err := evaluateOperands(root, left, right)
+		if err != nil {
+			return leftValue, err
+		}
+		return reflect.ValueOf(compare(leftValue, rightValue)), nil
+	}}, nil
+}
+
+// compileComparison function returns the comparison function of operator on values of leftType and rightType.
+func compileComparison(operator string, leftType reflect.Type, rightType reflect.Type) (func(leftValue reflect.Value, rightValue reflect.Value) bool, error) {
+	equality := operator == "==" || operator == "!="
+	var compare func(leftValue reflect.Value, rightValue reflect.Value) int
+	switch {
+	case isNumberType(leftType) && isNumberType(rightType):
+		if isIntegerType(leftType) && isIntegerType(rightType) {
+			compare = func(leftValue reflect.Value, rightValue reflect.Value) int {
+				return compareOrdered(toInt64(leftValue), toInt64(rightValue))
+			}
+		} else {
+			compare = func(leftValue reflect.Value, rightValue reflect.Value) int {
+				return compareOrdered(toFloat64(leftValue), toFloat64(rightValue))
+			}
+		}
+	case isKind(leftType, reflect.String) && isKind(rightType, reflect.String):
+		compare = func(leftValue reflect.Value, rightValue reflect.Value) int {
+			return strings.Compare(leftValue.String(), rightValue.String())
+		}
+	case equality && isKind(leftType, reflect.Bool) && isKind(rightType, reflect.Bool):
+		compare = func(leftValue reflect.Value, rightValue reflect.Value) int {
+			if leftValue.Bool() == rightValue.Bool() {
+				return 0
+			}
+			return 1
+		}
+	case equality && (leftType == nil) != (rightType == nil) &&
+		(leftType == nil || isNillableType(leftType)) && (rightType == nil || isNillableType(rightType)):
+		compare = func(leftValue reflect.Value, rightValue reflect.Value) int {
+			value := leftValue
+			if !value.IsValid() {
+				value = rightValue
+			}
+			if value.IsNil() {
+				return 0
+			}
+			return 1
+		}
+	case equality && leftType != nil && leftType == rightType && leftType.Comparable():
+		compare = func(leftValue reflect.Value, rightValue reflect.Value) int {
+			if leftValue.Equal(rightValue) {
+				return 0
+			}
+			return 1
+		}
+	default:
+		return nil, operatorError(operator, leftType, rightType)
+	}
+	return func(leftValue reflect.Value, rightValue reflect.Value) bool {
+		result := compare(leftValue, rightValue)
+		switch operator {
+		case "==":
+			return result == 0
+		case "!=":
+			return result != 0
+		case "<":
+			return result < 0
+		case "<=":
+			return result <= 0
+		case ">":
+			return result > 0
+		default:
+			return result >= 0
+		}
+	}, nil
+}
+
+// compareOrdered function returns -1, 0 or 1 if a is lower, equal or greater than b.
+func compareOrdered[T int64 | float64](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// arithmeticNode function returns an arithmetic node (or a string concatenation node).
+func arithmeticNode(text string, operator string, left *expressionNode, right *expressionNode) (*expressionNode, error) {
+	left, right = derefOperand(left), derefOperand(right)
+	node := &expressionNode{text: text}
+	var compute func(leftValue reflect.Value, rightValue reflect.Value) (reflect.Value, error)
+	switch {
+	case operator == "+" && isKind(left.valueType, reflect.String) && isKind(right.valueType, reflect.String):
+		node.valueType = stringType
+		compute = func(leftValue reflect.Value, rightValue reflect.Value) (reflect.Value, error) {
+			return reflect.ValueOf(leftValue.String() + rightValue.String()), nil
+		}
+	case isIntegerType(left.valueType) && isIntegerType(right.valueType):
+		node.valueType = int64Type
+		compute = func(leftValue reflect.Value, rightValue reflect.Value) (reflect.Value, error) {
+			a, b := toInt64(leftValue), toInt64(rightValue)
+			switch operator {
+			case "+":
+				return reflect.ValueOf(a + b), nil
+			case "-":
+				return reflect.ValueOf(a - b), nil
+			case "*":
+				return reflect.ValueOf(a * b), nil
+			}
+			if b == 0 {
+				return reflect.Value{}, fmt.Errorf("[%s] divides by zero", text)
+			}
+			if operator == "/" {
+				return reflect.ValueOf(a / b), nil
+			}
+			return reflect.ValueOf(a % b), nil
+		}
+	case operator != "%" && isNumberType(left.valueType) && isNumberType(right.valueType):
+		node.valueType = float64Type
+		compute = func(leftValue reflect.Value, rightValue reflect.Value) (reflect.Value, error) {
+			a, b := toFloat64(leftValue), toFloat64(rightValue)
+			switch operator {
+			case "+":
+				return reflect.ValueOf(a + b), nil
+			case "-":
+				return reflect.ValueOf(a - b), nil
+			case "*":
+				return reflect.ValueOf(a * b), nil
+			default:
+				return reflect.ValueOf(a / b), nil
+			}
+		}
+	default:
+		return nil, operatorError(operator, left.valueType, right.valueType)
+	}
+	node.evaluate = func(root reflect.Value) (reflect.Value, error) {
+		leftValue, rightValue, err := evaluateOperands(root, left, right)
+		if err != nil {
+			return leftValue, err
+		}
+		return compute(leftValue, rightValue)
+	}
+	return node, nil
+}
+
+// fieldNode function returns the node of the "name" field of parent structure.
+func fieldNode(text string, parent *expressionNode, name string) (*expressionNode, error) {
+	if parent.valueType == nil {
+		return nil, fmt.Errorf("[%s] field is not found on nil", name)
+	}
+	structType := derefType(parent.valueType)
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("[%s.%s] field is not found, [%s] type is not a structure",
+			typeName(structType), name, typeName(structType))
+	}
+	field, found := structType.FieldByName(name)
+	if !found {
+		return nil, fmt.Errorf("[%s.%s] field is not found", typeName(structType), name)
+	}
+	if !field.IsExported() {
+		return nil, fmt.Errorf("[%s.%s] field is private", typeName(structType), name)
+	}
+	return &expressionNode{text: text, valueType: field.Type, evaluate: func(root reflect.Value) (reflect.Value, error) {
+		value, err := parent.evaluate(root)
+		if err != nil {
+			return value, err
+		}
+		if value, err = derefValue(value, parent.text); err != nil {
+			return value, err
+		}
+		return value.FieldByIndexErr(field.Index)
+	}}, nil
+}
+
+// indexNode function returns the node of an indexed slice, array, string or map.
+func indexNode(text string, container *expressionNode, index *expressionNode) (*expressionNode, error) {
+	if container.valueType == nil {
+		return nil, fmt.Errorf("nil cannot be indexed")
+	}
+	containerType := derefType(container.valueType)
+	node := &expressionNode{text: text}
+	switch containerType.Kind() {
+	case reflect.Slice, reflect.Array, reflect.String:
+		if !isIntegerType(index.valueType) {
+			return nil, fmt.Errorf("[%s] index type is [%s], an integer is required",
+				index.text, expressionTypeName(index.valueType))
+		}
+		node.valueType = reflect.TypeOf(byte(0))
+		if containerType.Kind() != reflect.String {
+			node.valueType = containerType.Elem()
+		}
+	case reflect.Map:
+		if !isConvertible(index.valueType, containerType.Key()) {
+			return nil, fmt.Errorf("[%s] key type is [%s], [%s] is required",
+				index.text, expressionTypeName(index.valueType), typeName(containerType.Key()))
+		}
+		node.valueType = containerType.Elem()
+	default:
+		return nil, fmt.Errorf("[%s] type cannot be indexed", typeName(container.valueType))
+	}
+	node.evaluate = func(root reflect.Value) (reflect.Value, error) {
+		containerValue, indexValue, err := evaluateOperands(root, container, index)
+		if err != nil {
+			return containerValue, err
+		}
+		if containerValue, err = derefValue(containerValue, container.text); err != nil {
+			return containerValue, err
+		}
+		if containerValue.Kind() == reflect.Map {
+			element := containerValue.MapIndex(convertValue(indexValue, containerType.Key()))
+			if !element.IsValid() {
+				// Missing key: zero value
+				return reflect.Zero(node.valueType), nil
+			}
+			return element, nil
+		}
+		position := toInt64(indexValue)
+		if position < 0 || position >= int64(containerValue.Len()) {
+			return reflect.Value{}, fmt.Errorf("[%s] index %d is out of range [0:%d]", text, position, containerValue.Len())
+		}
+		return containerValue.Index(int(position)), nil
+	}
+	return node, nil
+}
+
+// methodNode function returns the node of the "name" method call on receiver.
+//
+// The method must return a single value, or a value and an error.
+func methodNode(text string, receiver *expressionNode, name string, arguments []*expressionNode) (*expressionNode, error) {
+	if receiver.valueType == nil {
+		return nil, fmt.Errorf("[%s] method is not found on nil", name)
+	}
+	receiverType := receiver.valueType
+	method, found := receiverType.MethodByName(name)
+	if !found && receiverType.Kind() != reflect.Interface {
+		receiverType = derefType(receiverType)
+		if method, found = receiverType.MethodByName(name); !found {
+			// Method with pointer receiver
+			method, found = reflect.PointerTo(receiverType).MethodByName(name)
+		}
+	}
+	// Errors name the structure type, not the pointer type
+	receiverType = derefType(receiverType)
+	if !found {
+		return nil, fmt.Errorf("[%s.%s] method is not found", typeName(receiverType), name)
+	}
+	methodType := method.Type
+	if receiverType.Kind() != reflect.Interface {
+		methodType = withoutReceiver(methodType)
+	}
+	if methodType.IsVariadic() {
+		return nil, fmt.Errorf("[%s.%s] method is variadic, variadic methods are not supported", typeName(receiverType), name)
+	}
+	if methodType.NumIn() != len(arguments) {
+		return nil, fmt.Errorf("[%s.%s] method requires %d argument(s), %d provided",
+			typeName(receiverType), name, methodType.NumIn(), len(arguments))
+	}
+	for index, argument := range arguments {
+		if !isConvertible(argument.valueType, methodType.In(index)) {
+			return nil, fmt.Errorf("[%s.%s] method argument %d type is [%s], [%s] is required",
+				typeName(receiverType), name, index+1, expressionTypeName(argument.valueType), typeName(methodType.In(index)))
+		}
+	}
+	returnsError := methodType.NumOut() == 2 && methodType.Out(1) == errorType
+	if methodType.NumOut() != 1 && !returnsError {
+		return nil, fmt.Errorf("[%s.%s] method must return a value and optionally an error", typeName(receiverType), name)
+	}
+	return &expressionNode{text: text, valueType: methodType.Out(0), evaluate: func(root reflect.Value) (reflect.Value, error) {
+		receiverValue, err := receiver.evaluate(root)
+		if err != nil {
+			return receiverValue, err
+		}
+		if (receiverValue.Kind() == reflect.Interface || receiverValue.Kind() == reflect.Ptr) && receiverValue.IsNil() {
+			return reflect.Value{}, fmt.Errorf("[%s] value is nil", receiver.text)
+		}
+		methodValue := receiverValue.MethodByName(name)
+		if !methodValue.IsValid() {
+			if receiverValue, err = derefValue(receiverValue, receiver.text); err != nil {
+				return receiverValue, err
+			}
+			if methodValue = receiverValue.MethodByName(name); !methodValue.IsValid() {
+				if !receiverValue.CanAddr() {
+					// Copy value to call a pointer receiver method
+					addressable := reflect.New(receiverValue.Type()).Elem()
+					addressable.Set(receiverValue)
+					receiverValue = addressable
+				}
+				methodValue = receiverValue.Addr().MethodByName(name)
+			}
+		}
+		argumentValues := make([]reflect.Value, len(arguments))
+		for index, argument := range arguments {
+			value, err := argument.evaluate(root)
+			if err != nil {
+				return value, err
+			}
+			argumentValues[index] = convertValue(value, methodType.In(index))
+		}
+		results := methodValue.Call(argumentValues)
+		if returnsError && !results[1].IsNil() {
+			return reflect.Value{}, fmt.Errorf("[%s] method returns an error: %w", text, results[1].Interface().(error))
+		}
+		return results[0], nil
+	}}, nil
+}
+
+// expressionFunction compiles a function call with its arguments.
+type expressionFunction func(text string, arguments []*expressionNode) (*expressionNode, error)
+
+// expressionFunctions are the functions available in expressions.
+var expressionFunctions map[string]expressionFunction
+
+func init() {
+	expressionFunctions = map[string]expressionFunction{
+		"len":       compileLen,
+		"lower":     stringFunction("lower", strings.ToLower),
+		"upper":     stringFunction("upper", strings.ToUpper),
+		"trim":      stringFunction("trim", strings.TrimSpace),
+		"contains":  compileContains,
+		"hasPrefix": stringPredicate("hasPrefix", strings.HasPrefix),
+		"hasSuffix": stringPredicate("hasSuffix", strings.HasSuffix),
+	}
+}
+
+// checkArguments function returns an error if function arguments count is not count.
+func checkArguments(name string, arguments []*expressionNode, count int) error {
+	if len(arguments) != count {
+		return fmt.Errorf("[%s] function requires %d argument(s), %d provided", name, count, len(arguments))
+	}
+	return nil
+}
+
+// compileLen function compiles "len(value)" function call (strings, slices, arrays and maps).
+func compileLen(text string, arguments []*expressionNode) (*expressionNode, error) {
+	if err := checkArguments("len", arguments, 1); err != nil {
+		return nil, err
+	}
+	argument := arguments[0]
+	if argument.valueType == nil {
+		return nil, fmt.Errorf("[len] function argument type is [nil], a string, a slice, an array or a map is required")
+	}
+	switch derefType(argument.valueType).Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		return nil, fmt.Errorf("[len] function argument type is [%s], a string, a slice, an array or a map is required",
+			typeName(argument.valueType))
+	}
+	return &expressionNode{text: text, valueType: intType, evaluate: func(root reflect.Value) (reflect.Value, error) {
+		value, err := argument.evaluate(root)
+		if err != nil {
+			return value, err
+		}
+		if value, err = derefValue(value, argument.text); err != nil {
+			return value, err
+		}
+		return reflect.ValueOf(value.Len()), nil
+	}}, nil
+}
+
+// stringFunction function returns the compiler of a string transformation function call.
+func stringFunction(name string, transform func(string) string) expressionFunction {
+	return func(text string, arguments []*expressionNode) (*expressionNode, error) {
+		if err := checkArguments(name, arguments, 1); err != nil {
+			return nil, err
+		}
+		argument := derefOperand(arguments[0])
+		if !isKind(argument.valueType, reflect.String) {
+			return nil, fmt.Errorf("[%s] function argument type is [%s], a string is required",
+				name, expressionTypeName(argument.valueType))
+		}
+		return &expressionNode{text: text, valueType: stringType, evaluate: func(root reflect.Value) (reflect.Value, error) {
+			value, err := argument.evaluate(root)
+			if err != nil {
+				return value, err
+			}
+			return reflect.ValueOf(transform(value.String())), nil
+		}}, nil
+	}
+}
+
+// stringPredicate function returns the compiler of a string predicate function call.
+func stringPredicate(name string, predicate func(string, string) bool) expressionFunction {
+	return func(text string, arguments []*expressionNode) (*expressionNode, error) {
+		if err := checkArguments(name, arguments, 2); err != nil {
+			return nil, err
+		}
+		left, right := derefOperand(arguments[0]), derefOperand(arguments[1])
+		if !isKind(left.valueType, reflect.String) || !isKind(right.valueType, reflect.String) {
+			return nil, fmt.Errorf("[%s] function argument types are [%s] and [%s], strings are required",
+				name, expressionTypeName(left.valueType), expressionTypeName(right.valueType))
+		}
+		return &expressionNode{text: text, valueType: boolType, evaluate: func(root reflect.Value) (reflect.Value, error) {
+			leftValue, rightValue, err := evaluateOperands(root, left, right)
+			if err != nil {
+				return leftValue, err
+			}
+			return reflect.ValueOf(predicate(leftValue.String(), rightValue.String())), nil
+		}}, nil
+	}
+}
+
+// compileContains function compiles "contains(text, substring)" and "contains(slice, element)" function calls.
+func compileContains(text string, arguments []*expressionNode) (*expressionNode, error) {
+	if err := checkArguments("contains", arguments, 2); err != nil {
+		return nil, err
+	}
+	if isKind(derefOperand(arguments[0]).valueType, reflect.String) {
+		return stringPredicate("contains", strings.Contains)(text, arguments)
+	}
+	container, element := arguments[0], derefOperand(arguments[1])
+	if container.valueType == nil {
+		return nil, fmt.Errorf("[contains] function argument type is [nil], a string, a slice or an array is required")
+	}
+	containerType := derefType(container.valueType)
+	if containerType.Kind() != reflect.Slice && containerType.Kind() != reflect.Array {
+		return nil, fmt.Errorf("[contains] function argument type is [%s], a string, a slice or an array is required",
+			typeName(container.valueType))
+	}
+	equal, err := compileComparison("==", containerType.Elem(), element.valueType)
+	if err != nil {
+		return nil, fmt.Errorf("[contains] function element type is [%s], [%s] is required",
+			expressionTypeName(element.valueType), typeName(containerType.Elem()))
+	}
+	return &expressionNode{text: text, valueType: boolType, evaluate: func(root reflect.Value) (reflect.Value, error) {
+		containerValue, elementValue, err := evaluateOperands(root, container, element)
+		if err != nil {
+			return containerValue, err
+		}
+		if containerValue, err = derefValue(containerValue, container.text); err != nil {
+			return containerValue, err
+		}
+		for index := 0; index < containerValue.Len(); index++ {
+			if equal(containerValue.Index(index), elementValue) {
+				return reflect.ValueOf(true), nil
+			}
+		}
+		return reflect.ValueOf(false), nil
+	}}, nil
+}
@@ -0,0 +1,214 @@
+package bvmgo_reflect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testExpressionStatus string
+
+type testExpressionAddress struct {
+	City    string
+	Country string
+}
+
+func (address testExpressionAddress) Format(separator string) string {
+	return address.City + separator + address.Country
+}
+
+type testExpressionAccount struct {
+	Status   testExpressionStatus
+	Tags     []string
+	Labels   map[string]int
+	Age      uint8
+	Score    float64
+	Address  *testExpressionAddress
+	Previous *testExpressionAddress
+	Owner    fmt.Stringer
+	Count    *int
+	Nickname *string
+	Verified *bool
+	visits   int
+}
+
+func (account *testExpressionAccount) IsAdult() bool {
+	return account.Age >= 18
+}
+
+func (account testExpressionAccount) Label(name string) (int, error) {
+	value, found := account.Labels[name]
+	if !found {
+		return 0, errors.New("label not found")
+	}
+	return value, nil
+}
+
+func TestCompile_evaluate(t *testing.T) {
+	count, nickname, verified := 3, "b", true
+	account := &testExpressionAccount{Status: "active", Tags: []string{"a", "b", "c"}, Labels: map[string]int{"env": 2},
+		Age: 30, Score: 2.5, Address: &testExpressionAddress{City: "Paris", Country: "FR"},
+		Count: &count, Nickname: &nickname, Verified: &verified}
+	tests := []struct {
+		source string
+		want   any
+	}{
+		{`Status == "active" && len(Tags) > 2`, true},
+		{`Status != "active" || len(Tags) > 5`, false},
+		{`!(Age < 18)`, true},
+		{`Age + 2 * 3`, int64(36)},
+		{`(Age + 2) / 4 % 5`, int64(3)},
+		{`Score * 2 - 1`, 4.0},
+		{`Age / 4.0`, 7.5},
+		{`-Age`, int64(-30)},
+		{`Tags[1] + "-" + Tags[2]`, "b-c"},
+		{`Labels["env"] == 2 && Labels["missing"] == 0`, true},
+		{`Address.City`, "Paris"},
+		{`Address.Format(", ")`, "Paris, FR"},
+		{`IsAdult()`, true},
+		{`Label("env") >= 2`, true},
+		{`Previous == nil && Address != nil && Owner == nil`, true},
+		{`upper(Address.Country) + lower("AB") + trim(" x ")`, "FRabx"},
+		{`contains(Tags, "b") && !contains(Tags, "z") && contains(Address.City, "ar")`, true},
+		{`hasPrefix(Address.City, "Pa") && hasSuffix(Address.City, "is")`, true},
+		{`len(Address.City) == 5 && len(Labels) == 1`, true},
+		{"Status > `a` && 1.5e1 == 15", true},
+		{`"abc"[1]`, uint8('b')},
+		{`Count > 2 && Count != nil && Nickname == "b" && Verified && !!Verified`, true},
+		{`Count * 2 + Score`, 8.5},
+		{`upper(Nickname) + Nickname`, "Bb"},
+		{`contains(Tags, Nickname) && contains(Nickname, "b") && hasPrefix(Nickname, "b")`, true},
+		{`0x10 + 0o10 + 0b10 + 1_000 + 0`, int64(1026)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			expression, err := CompileOf[testExpressionAccount](tt.source)
+			if err != nil {
+				t.Errorf("CompileOf(%s) returns \"%v\" error, want no error", tt.source, err)
+				return
+			}
+			got, err := expression.Evaluate(account)
+			if err != nil {
+				t.Errorf("Evaluate(...) returns \"%v\" error, want no error", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate(...) = %#v, want %#v", got, tt.want)
+			}
+			if expression.Type() != reflect.TypeOf(tt.want) {
+				t.Errorf("Type() = %v, want %v", expression.Type(), reflect.TypeOf(tt.want))
+			}
+		})
+	}
+}
+
+func TestCompile_errors(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{`Unknown == 1`, "[bvmgo_reflect.testExpressionAccount.Unknown] field is not found"},
+		{`visits > 1`, "[bvmgo_reflect.testExpressionAccount.visits] field is private"},
+		{`Status == 1`, "[==] operator is not defined on [bvmgo_reflect.testExpressionStatus] and [int64] types"},
+		{`Status && true`, "[&&] operator is not defined"},
+		{`Tags["a"]`, "[\"a\"] index type is [string], an integer is required"},
+		{`Labels[1]`, "[1] key type is [int64], [string] is required"},
+		{`Age.Value`, "[uint8.Value] field is not found"},
+		{`Address.Format(1)`, "[bvmgo_reflect.testExpressionAddress.Format] method argument 1 type is [int64], [string] is required"},
+		{`Address.Unknown()`, "[bvmgo_reflect.testExpressionAddress.Unknown] method is not found"},
+		{`len(Age)`, "[len] function argument type is [uint8]"},
+		{`Age >`, "position 5: unexpected [end of expression]"},
+		{`(Age`, "[)] expected, [end of expression] found"},
+		{`Age $ 1`, "position 4: unexpected [$] character"},
+		{`"abc`, "string is not terminated"},
+		{`Age 1`, "unexpected [1]"},
+		{`Age == 010`, "position 7: invalid [010] integer, leading zeros are not allowed"},
+		{`Age == 0x1g`, "position 7: invalid [0x1g] integer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			_, err := CompileOf[testExpressionAccount](tt.source)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("CompileOf(%s) returns \"%v\" error, want \"%s\" error", tt.source, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpression_evaluateErrors(t *testing.T) {
+	account := testExpressionAccount{Tags: []string{"a"}}
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{`Address.City == ""`, "[Address] value is nil"},
+		{`Tags[3] == ""`, "[Tags[3]] index 3 is out of range [0:1]"},
+		{`Age / Age`, "[Age / Age] divides by zero"},
+		{`Label("x") > 0`, "[Label(\"x\")] method returns an error: label not found"},
+		{`Count > 2`, "[Count] value is nil"},
+		{`upper(Nickname)`, "[Nickname] value is nil"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			expression, err := CompileOf[testExpressionAccount](tt.source)
+			if err != nil {
+				t.Errorf("CompileOf(%s) returns \"%v\" error, want no error", tt.source, err)
+				return
+			}
+			if _, err = expression.Evaluate(account); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Evaluate(...) returns \"%v\" error, want \"%s\" error", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpression_EvaluateBool(t *testing.T) {
+	expression, err := Compile(`Status == "active" && IsAdult()`, reflect.TypeOf(&testExpressionAccount{}))
+	if err != nil {
+		t.Errorf("Compile(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	accounts := []testExpressionAccount{{Status: "active", Age: 20}, {Status: "active", Age: 10}, {Status: "closed", Age: 40}}
+	var matches []bool
+	for _, account := range accounts {
+		match, err := expression.EvaluateBool(account)
+		if err != nil {
+			t.Errorf("EvaluateBool(...) returns \"%v\" error, want no error", err)
+			return
+		}
+		matches = append(matches, match)
+	}
+	if !reflect.DeepEqual(matches, []bool{true, false, false}) {
+		t.Errorf("EvaluateBool(...) = %v, want [true false false]", matches)
+	}
+	if _, err = expression.EvaluateBool(testExpressionAddress{}); err == nil {
+		t.Errorf("EvaluateBool(testExpressionAddress) returns no error, want an error")
+	}
+	notBool, _ := CompileOf[testExpressionAccount](`Age`)
+	if _, err = notBool.EvaluateBool(accounts[0]); err == nil {
+		t.Errorf("EvaluateBool(...) on uint8 expression returns no error, want an error")
+	}
+}
+
+func TestExpression_interfaceMethod(t *testing.T) {
+	expression, err := CompileOf[testExpressionAccount](`Owner.String() == "alice"`)
+	if err != nil {
+		t.Errorf("CompileOf(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	owner := testExpressionAddress{City: "alice"}
+	if match, err := expression.EvaluateBool(testExpressionAccount{Owner: testExpressionOwner(owner)}); err != nil || !match {
+		t.Errorf("EvaluateBool(...) = %v, \"%v\" error, want true and no error", match, err)
+	}
+	if _, err = expression.EvaluateBool(testExpressionAccount{}); err == nil || !strings.Contains(err.Error(), "[Owner] value is nil") {
+		t.Errorf("EvaluateBool(...) returns \"%v\" error, want \"[Owner] value is nil\" error", err)
+	}
+}
+
+type testExpressionOwner testExpressionAddress
+
+func (owner testExpressionOwner) String() string {
+	return owner.City
+}
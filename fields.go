@@ -0,0 +1,123 @@
+package bvmgo_reflect
+
+import (
+	"reflect"
+	"strings"
+)
+
+// fieldCollector collects the fields of a structure named by a tag, following Go promoted fields rules.
+type fieldCollector struct {
+	// tagKey is the key of the tag naming fields (`json:"name,options"`).
+	tagKey string
+	// defaultName function returns the name of a field without tag name.
+	defaultName func(fieldName string) string
+	// isStruct function returns true if the fields of a structure type are collected (embedded or nested).
+	isStruct func(structType reflect.Type) bool
+	// nestedSeparator, if not empty, joins the names of nested structures fields to their own names
+	// ("address_city" for Address.City), else nested structures are fields.
+	nestedSeparator string
+}
+
+// collectedField is a field collected by fieldCollector.
+type collectedField struct {
+	name       string
+	tagged     bool
+	tagOptions string
+	field      reflect.StructField
+	index      []int
+}
+
+// collect function returns the fields of structType, index is the field index path from structType.
+//
+// Fields tagged "-" and unexported fields are ignored. Fields of embedded structures (and structure pointers)
+// without tag name are promoted, an embedded or nested structure already walked by an enclosing structure
+// (cycle, like `type Node struct{ *Node }`) is ignored.
+// Like Go promoted fields and encoding/json, the shallowest field of a name wins; fields of the same name
+// at the same depth are ambiguous and ignored, unless only one of them is tagged.
+func (collector fieldCollector) collect(structType reflect.Type) []collectedField {
+	var candidates []collectedField
+	walking := map[reflect.Type]bool{structType: true}
+	var walk func(currentType reflect.Type, parentIndex []int, prefix string)
+	walk = func(currentType reflect.Type, parentIndex []int, prefix string) {
+		for index := 0; index < currentType.NumField(); index++ {
+			field := currentType.Field(index)
+			tag := field.Tag.Get(collector.tagKey)
+			if tag == "-" {
+				continue
+			}
+			name, tagOptions, _ := strings.Cut(tag, ",")
+			fieldIndex := append(append([]int{}, parentIndex...), index)
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			isStruct := fieldType.Kind() == reflect.Struct && collector.isStruct(fieldType)
+			if field.Anonymous && len(name) == 0 && isStruct {
+				if !walking[fieldType] {
+					walking[fieldType] = true
+					walk(fieldType, fieldIndex, prefix)
+					delete(walking, fieldType)
+				}
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			tagged := len(name) > 0
+			if !tagged {
+				name = collector.defaultName(field.Name)
+			}
+			if len(collector.nestedSeparator) > 0 && isStruct {
+				if !walking[fieldType] {
+					walking[fieldType] = true
+					walk(fieldType, fieldIndex, prefix+name+collector.nestedSeparator)
+					delete(walking, fieldType)
+				}
+				continue
+			}
+			candidates = append(candidates, collectedField{
+				name:       prefix + name,
+				tagged:     tagged,
+				tagOptions: tagOptions,
+				field:      field,
+				index:      fieldIndex,
+			})
+		}
+	}
+	walk(structType, nil, "")
+	// Select the dominant field of each name
+	dominants := make(map[string]int)
+	for candidate := range candidates {
+		dominant, exists := dominants[candidates[candidate].name]
+		if !exists || isDominantField(candidates[candidate], candidates[dominant]) {
+			dominants[candidates[candidate].name] = candidate
+		}
+	}
+	var fields []collectedField
+	for candidate, field := range candidates {
+		if dominants[field.name] != candidate {
+			continue
+		}
+		ambiguous := false
+		for other, otherField := range candidates {
+			if other != candidate && otherField.name == field.name && len(otherField.index) == len(field.index) &&
+				otherField.tagged == field.tagged {
+				ambiguous = true
+				break
+			}
+		}
+		if !ambiguous {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// isDominantField function returns true if field hides current field of the same name:
+// a shallower field, or a tagged field at the same depth.
+func isDominantField(field collectedField, current collectedField) bool {
+	if len(field.index) != len(current.index) {
+		return len(field.index) < len(current.index)
+	}
+	return field.tagged && !current.tagged
+}
@@ -0,0 +1,46 @@
+package bvmgo_reflect
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type testFieldsFirst struct {
+	Name string
+	ID   int
+}
+
+type testFieldsSecond struct {
+	Name string
+	Code string `json:"ID"`
+}
+
+type testFieldsConflict struct {
+	testFieldsFirst
+	testFieldsSecond
+	Label string
+}
+
+func TestFieldCollector_conflicts(t *testing.T) {
+	fields := jsonFields(reflect.TypeOf(testFieldsConflict{}))
+	var names []string
+	for _, field := range fields {
+		names = append(names, field.name)
+	}
+	if !reflect.DeepEqual(names, []string{"ID", "Label"}) {
+		t.Errorf("jsonFields(...) names = %v, want %v", names, []string{"ID", "Label"})
+		return
+	}
+	if !reflect.DeepEqual(fields[0].index, []int{1, 1}) {
+		t.Errorf("jsonFields(...)[ID] index = %v, want %v", fields[0].index, []int{1, 1})
+	}
+	// Same properties as encoding/json
+	value := testFieldsConflict{testFieldsFirst{Name: "a", ID: 1}, testFieldsSecond{Name: "b", Code: "c"}, "d"}
+	expected, _ := json.Marshal(value)
+	var properties map[string]any
+	_ = json.Unmarshal(expected, &properties)
+	if len(properties) != len(fields) {
+		t.Errorf("json.Marshal(...) = %s, want %d properties", expected, len(fields))
+	}
+}
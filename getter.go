@@ -65,7 +65,27 @@ func getFieldValue(sourceStructure any, fieldName string) (fieldValue reflect.Va
 //   - fieldName is not found on sourceStructure structure (or map),
 //   - fieldName is not a string.
 func GetFieldString(sourceStructure any, fieldName string) (value string, err error) {
-	fieldValue, err := getFieldValue(sourceStructure, fieldName)
+	return GetFieldStringWith(sourceStructure, fieldName, FieldOptions{})
+}
+
+// GetFieldStringWith function returns structure "fieldName" field value with options.
+//
+// If options.UseAccessors is true and structure has no exported field named fieldName,
+// the value is read with a "GetX()" or "X()" getter method.
+//
+// See GetFieldString function.
+func GetFieldStringWith(sourceStructure any, fieldName string, options FieldOptions) (value string, err error) {
+	var fieldValue reflect.Value
+	found := false
+	if options.UseAccessors {
+		fieldValue, found, err = callGetter(sourceStructure, fieldName)
+		if err != nil {
+			return
+		}
+	}
+	if !found {
+		fieldValue, err = getFieldValue(sourceStructure, fieldName)
+	}
 	// Check field exists
 	if err != nil {
 		return
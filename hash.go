@@ -0,0 +1,235 @@
+package bvmgo_reflect
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"slices"
+)
+
+// Hash function returns a deterministic hash (64-bit FNV-1a) of the structure of v.
+//
+// The hash is stable across process runs:
+//   - map entries are hashed independently of their order,
+//   - slices and arrays tagged `hash:"set"` are hashed independently of their elements order,
+//   - fields tagged `hash:"-"` are ignored,
+//   - pointers are hashed with their pointed values,
+//   - pointers, maps and slices containing themselves (cycles) are hashed with the depth of their ancestor,
+//   - encoding.TextMarshaler values (like time.Time) are hashed with their text.
+//
+// Hash function returns an error if v contains a not nil function, channel or unsafe pointer.
+func Hash(v any) (uint64, error) {
+	hasher := valueHasher{ancestors: make(map[visitedPointer]int)}
+	digest := fnv.New64a()
+	if err := hasher.hashValue(digest, reflect.ValueOf(v), true); err != nil {
+		return 0, err
+	}
+	return digest.Sum64(), nil
+}
+
+// valueHasher hashes values and tracks the pointers of the current path (cycles detection).
+type valueHasher struct {
+	ancestors map[visitedPointer]int
+}
+
+// writeUint64 function writes a number to digest.
+func writeUint64(digest hash.Hash64, number uint64) {
+	var buffer [8]byte
+	binary.LittleEndian.PutUint64(buffer[:], number)
+	digest.Write(buffer[:])
+}
+
+// writeString function writes a string (prefixed by its length) to digest.
+func writeString(digest hash.Hash64, text string) {
+	writeUint64(digest, uint64(len(text)))
+	digest.Write([]byte(text))
+}
+
+// hashValue function writes value to digest, with its type name if withType is true.
+func (hasher *valueHasher) hashValue(digest hash.Hash64, value reflect.Value, withType bool) error {
+	if !value.IsValid() {
+		digest.Write([]byte{byte(reflect.Invalid)})
+		return nil
+	}
+	digest.Write([]byte{byte(value.Kind())})
+	if withType {
+		writeString(digest, typeName(value.Type()))
+	}
+	if value.CanInterface() && value.Kind() != reflect.Ptr && value.Kind() != reflect.Interface {
+		if text, ok, err := marshalText(value); ok || err != nil {
+			if err != nil {
+				return err
+			}
+			writeString(digest, text)
+			return nil
+		}
+	}
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			digest.Write([]byte{1})
+		} else {
+			digest.Write([]byte{0})
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(digest, uint64(value.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(digest, value.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeUint64(digest, floatBits(value.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		writeUint64(digest, floatBits(real(value.Complex())))
+		writeUint64(digest, floatBits(imag(value.Complex())))
+	case reflect.String:
+		writeString(digest, value.String())
+	case reflect.Interface:
+		if value.IsNil() {
+			digest.Write([]byte{0})
+			return nil
+		}
+		digest.Write([]byte{1})
+		// Dynamic type is part of the hash
+		return hasher.hashValue(digest, value.Elem(), true)
+	case reflect.Ptr:
+		if value.IsNil() {
+			digest.Write([]byte{0})
+			return nil
+		}
+		if hasher.writeCycle(digest, value) {
+			return nil
+		}
+		digest.Write([]byte{1})
+		defer hasher.leave(value)
+		return hasher.hashValue(digest, value.Elem(), false)
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			digest.Write([]byte{0})
+			return nil
+		}
+		if value.Kind() == reflect.Slice && value.Len() > 0 {
+			if hasher.writeCycle(digest, value) {
+				return nil
+			}
+			defer hasher.leave(value)
+		}
+		digest.Write([]byte{1})
+		writeUint64(digest, uint64(value.Len()))
+		for index := 0; index < value.Len(); index++ {
+			if err := hasher.hashValue(digest, value.Index(index), false); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if value.IsNil() {
+			digest.Write([]byte{0})
+			return nil
+		}
+		if hasher.writeCycle(digest, value) {
+			return nil
+		}
+		defer hasher.leave(value)
+		digest.Write([]byte{1})
+		entries := make([]uint64, 0, value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			entryDigest := fnv.New64a()
+			if err := hasher.hashValue(entryDigest, iterator.Key(), false); err != nil {
+				return err
+			}
+			if err := hasher.hashValue(entryDigest, iterator.Value(), false); err != nil {
+				return err
+			}
+			entries = append(entries, entryDigest.Sum64())
+		}
+		writeUnordered(digest, entries)
+	case reflect.Struct:
+		structType := value.Type()
+		for index := 0; index < structType.NumField(); index++ {
+			field := structType.Field(index)
+			tag := field.Tag.Get("hash")
+			if tag == "-" {
+				continue
+			}
+			writeString(digest, field.Name)
+			fieldValue := value.Field(index)
+			var err error
+			if hasTagOption(tag, "set") {
+				err = hasher.hashSet(digest, fieldValue)
+			} else {
+				err = hasher.hashValue(digest, fieldValue, false)
+			}
+			if err != nil {
+				return fmt.Errorf("[%s.%s] field cannot be hashed: %w", typeName(structType), field.Name, err)
+			}
+		}
+	default:
+		if value.IsNil() {
+			digest.Write([]byte{0})
+			return nil
+		}
+		return fmt.Errorf("unsupported type [%s]", typeName(value.Type()))
+	}
+	return nil
+}
+
+// writeCycle function writes the depth of the ancestor if the pointer, map or slice value is being hashed
+// (cycle), or marks value as an ancestor.
+//
+// The depth of the ancestor is stable across process runs, not its address.
+func (hasher *valueHasher) writeCycle(digest hash.Hash64, value reflect.Value) bool {
+	key := visitedPointer{pointer: value.Pointer(), pointerType: value.Type()}
+	if depth, ok := hasher.ancestors[key]; ok {
+		digest.Write([]byte{2})
+		writeUint64(digest, uint64(depth))
+		return true
+	}
+	hasher.ancestors[key] = len(hasher.ancestors)
+	return false
+}
+
+// leave function removes the pointer, map or slice value from the ancestors.
+func (hasher *valueHasher) leave(value reflect.Value) {
+	delete(hasher.ancestors, visitedPointer{pointer: value.Pointer(), pointerType: value.Type()})
+}
+
+// hashSet function writes a slice or an array to digest, independently of its elements order.
+func (hasher *valueHasher) hashSet(digest hash.Hash64, value reflect.Value) error {
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return fmt.Errorf("unsupported type [%s], a slice or an array is required for a set", typeName(value.Type()))
+	}
+	digest.Write([]byte{byte(value.Kind())})
+	elements := make([]uint64, 0, value.Len())
+	for index := 0; index < value.Len(); index++ {
+		elementDigest := fnv.New64a()
+		if err := hasher.hashValue(elementDigest, value.Index(index), false); err != nil {
+			return err
+		}
+		elements = append(elements, elementDigest.Sum64())
+	}
+	writeUnordered(digest, elements)
+	return nil
+}
+
+// writeUnordered function writes hashes to digest, independently of their order.
+func writeUnordered(digest hash.Hash64, hashes []uint64) {
+	slices.Sort(hashes)
+	writeUint64(digest, uint64(len(hashes)))
+	for _, entryHash := range hashes {
+		writeUint64(digest, entryHash)
+	}
+}
+
+// floatBits function returns the bits of a float, with a single zero and a single NaN.
+func floatBits(number float64) uint64 {
+	switch {
+	case number == 0:
+		return 0
+	case math.IsNaN(number):
+		return math.Float64bits(math.NaN())
+	default:
+		return math.Float64bits(number)
+	}
+}
@@ -0,0 +1,123 @@
+package bvmgo_reflect
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testHashConfig struct {
+	Name    string
+	Labels  map[int]string
+	Roles   []string `hash:"set"`
+	Order   []string
+	Updated time.Time `hash:"-"`
+	Start   time.Time
+	Parent  *testHashConfig
+	Value   any
+}
+
+type testHashInvalid struct {
+	Callback func()
+}
+
+func TestHash(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	base := func() *testHashConfig {
+		return &testHashConfig{Name: "app", Labels: map[int]string{1: "a", 2: "b", 3: "c"},
+			Roles: []string{"admin", "user"}, Order: []string{"x", "y"}, Start: start, Value: 1}
+	}
+	baseHash, err := Hash(base())
+	if err != nil {
+		t.Errorf("Hash(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	tests := []struct {
+		name   string
+		modify func(config *testHashConfig)
+		same   bool
+	}{
+		{"Same", func(config *testHashConfig) {}, true},
+		{"Map order", func(config *testHashConfig) { config.Labels = map[int]string{3: "c", 2: "b", 1: "a"} }, true},
+		{"Set order", func(config *testHashConfig) { config.Roles = []string{"user", "admin"} }, true},
+		{"Ignored field", func(config *testHashConfig) { config.Updated = time.Now() }, true},
+		{"Name", func(config *testHashConfig) { config.Name = "other" }, false},
+		{"Map value", func(config *testHashConfig) { config.Labels[1] = "z" }, false},
+		{"Slice order", func(config *testHashConfig) { config.Order = []string{"y", "x"} }, false},
+		{"Time", func(config *testHashConfig) { config.Start = start.Add(time.Second) }, false},
+		{"Interface type", func(config *testHashConfig) { config.Value = int64(1) }, false},
+		{"Nil slice", func(config *testHashConfig) { config.Order = nil }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := base()
+			tt.modify(config)
+			got, err := Hash(config)
+			if err != nil {
+				t.Errorf("Hash(...) returns \"%v\" error, want no error", err)
+				return
+			}
+			if (got == baseHash) != tt.same {
+				t.Errorf("Hash(...) = %d, base hash = %d, want same = %v", got, baseHash, tt.same)
+			}
+		})
+	}
+}
+
+func TestHash_stable(t *testing.T) {
+	// Hashes must not change across process runs
+	got, err := Hash(map[string]int{"a": 1, "b": 2})
+	if err != nil {
+		t.Errorf("Hash(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	for index := 0; index < 10; index++ {
+		if other, _ := Hash(map[string]int{"b": 2, "a": 1}); other != got {
+			t.Errorf("Hash(...) = %d, want %d", other, got)
+		}
+	}
+}
+
+func TestHash_cycle(t *testing.T) {
+	a := &testHashConfig{Name: "a"}
+	a.Parent = a
+	b := &testHashConfig{Name: "a"}
+	b.Parent = b
+	hashA, errA := Hash(a)
+	hashB, errB := Hash(b)
+	if errA != nil || errB != nil {
+		t.Errorf("Hash(...) returns \"%v\" and \"%v\" errors, want no error", errA, errB)
+		return
+	}
+	if hashA != hashB {
+		t.Errorf("Hash(a) = %d, Hash(b) = %d, want same hashes", hashA, hashB)
+	}
+	mapA := map[string]any{"name": "a"}
+	mapA["self"] = mapA
+	mapB := map[string]any{"name": "a"}
+	mapB["self"] = mapB
+	hashA, errA = Hash(mapA)
+	hashB, errB = Hash(mapB)
+	if errA != nil || errB != nil || hashA != hashB {
+		t.Errorf("Hash(map cycle) = %d (%v) and %d (%v), want same hashes and no error", hashA, errA, hashB, errB)
+	}
+	listA := []any{"a", nil}
+	listA[1] = listA
+	listB := []any{"b", nil}
+	listB[1] = listB
+	hashA, errA = Hash(listA)
+	hashB, errB = Hash(listB)
+	if errA != nil || errB != nil || hashA == hashB {
+		t.Errorf("Hash(slice cycle) = %d (%v) and %d (%v), want different hashes and no error", hashA, errA, hashB, errB)
+	}
+}
+
+func TestHash_unsupported(t *testing.T) {
+	if _, err := Hash(testHashInvalid{}); err != nil {
+		t.Errorf("Hash(nil function) returns \"%v\" error, want no error", err)
+	}
+	_, err := Hash(testHashInvalid{Callback: func() {}})
+	if err == nil || !strings.Contains(err.Error(), "[bvmgo_reflect.testHashInvalid.Callback] field cannot be hashed") {
+		t.Errorf("Hash(function) returns \"%v\" error, want field cannot be hashed error", err)
+	}
+}
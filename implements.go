@@ -0,0 +1,117 @@
+package bvmgo_reflect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ImplementsReport explains why a type does not implement an interface.
+type ImplementsReport struct {
+	// Type is the checked type.
+	Type reflect.Type
+	// Interface is the interface type.
+	Interface reflect.Type
+	// NotInterface is true if Interface is not an interface type.
+	NotInterface bool
+	// Missing are the names of interface methods not found on Type.
+	Missing []string
+	// Mismatches are the interface methods found on Type with another signature.
+	Mismatches []MethodMismatch
+	// PointerReceiver are the names of interface methods only available on the pointer of Type.
+	PointerReceiver []string
+}
+
+// MethodMismatch describes a method whose signature does not match the interface method signature.
+type MethodMismatch struct {
+	// Name is the method name.
+	Name string
+	// Expected is the interface method signature ("(string) error").
+	Expected string
+	// Actual is the type method signature.
+	Actual string
+}
+
+// Implements function returns true if checkedType implements interfaceType,
+// and a report explaining why it does not.
+//
+// The report lists missing methods, methods with another signature (expected vs actual, rendered with TypeName)
+// and methods only available on the pointer receiver.
+func Implements(checkedType reflect.Type, interfaceType reflect.Type) (bool, ImplementsReport) {
+	report := ImplementsReport{Type: checkedType, Interface: interfaceType}
+	if interfaceType == nil || interfaceType.Kind() != reflect.Interface {
+		report.NotInterface = true
+		return false, report
+	}
+	if checkedType == nil {
+		report.Missing = make([]string, interfaceType.NumMethod())
+		for index := range report.Missing {
+			report.Missing[index] = interfaceType.Method(index).Name
+		}
+		return len(report.Missing) == 0, report
+	}
+	if checkedType.Implements(interfaceType) {
+		return true, report
+	}
+	description := Describe(checkedType)
+	isPointer := checkedType.Kind() == reflect.Ptr
+	formatter := typeNameFormatter{}
+	for index := 0; index < interfaceType.NumMethod(); index++ {
+		expectedMethod := interfaceType.Method(index)
+		method, found := description.Method(expectedMethod.Name)
+		switch {
+		case !found:
+			report.Missing = append(report.Missing, expectedMethod.Name)
+		case method.Type != expectedMethod.Type:
+			report.Mismatches = append(report.Mismatches, MethodMismatch{
+				Name:     expectedMethod.Name,
+				Expected: formatter.formatSignature(expectedMethod.Type),
+				Actual:   method.Signature,
+			})
+		case method.PointerReceiver && !isPointer:
+			report.PointerReceiver = append(report.PointerReceiver, expectedMethod.Name)
+		}
+	}
+	return false, report
+}
+
+// ImplementsOf function returns true if T type implements I interface, and a report explaining why it does not.
+//
+// See Implements function.
+func ImplementsOf[T any, I any]() (bool, ImplementsReport) {
+	return Implements(reflect.TypeOf((*T)(nil)).Elem(), reflect.TypeOf((*I)(nil)).Elem())
+}
+
+// Err function returns an error describing the report problems, or nil if there is no problem.
+func (report ImplementsReport) Err() error {
+	if !report.NotInterface && len(report.Missing) == 0 && len(report.Mismatches) == 0 &&
+		len(report.PointerReceiver) == 0 {
+		return nil
+	}
+	return errors.New(report.String())
+}
+
+// String function returns the report as text.
+func (report ImplementsReport) String() string {
+	if report.NotInterface {
+		return fmt.Sprintf("[%s] type is not an interface", typeName(report.Interface))
+	}
+	var problems []string
+	for _, name := range report.Missing {
+		problems = append(problems, fmt.Sprintf("[%s] method is missing", name))
+	}
+	for _, mismatch := range report.Mismatches {
+		problems = append(problems, fmt.Sprintf("[%s] method signature is [%s], expected [%s]",
+			mismatch.Name, mismatch.Actual, mismatch.Expected))
+	}
+	for _, name := range report.PointerReceiver {
+		problems = append(problems, fmt.Sprintf("[%s] method has a pointer receiver, use [%s] type",
+			name, typeName(reflect.PointerTo(report.Type))))
+	}
+	if len(problems) == 0 {
+		return fmt.Sprintf("[%s] type implements [%s] interface", typeName(report.Type), typeName(report.Interface))
+	}
+	return fmt.Sprintf("[%s] type does not implement [%s] interface: %s",
+		typeName(report.Type), typeName(report.Interface), strings.Join(problems, ", "))
+}
@@ -0,0 +1,76 @@
+package bvmgo_reflect
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testImplementsPlugin interface {
+	Name() string
+	Start(port int) error
+	Stop() error
+}
+
+type testImplementsGood struct{}
+
+func (plugin testImplementsGood) Name() string      { return "good" }
+func (plugin testImplementsGood) Start(_ int) error { return nil }
+func (plugin *testImplementsGood) Stop() error      { return nil }
+
+type testImplementsBad struct{}
+
+func (plugin testImplementsBad) Name() string         { return "bad" }
+func (plugin testImplementsBad) Start(_ string) error { return nil }
+
+func TestImplements(t *testing.T) {
+	ok, report := ImplementsOf[*testImplementsGood, testImplementsPlugin]()
+	if !ok || report.Err() != nil {
+		t.Errorf("Implements() = %v (%v), want true", ok, report)
+	}
+}
+
+func TestImplements_pointerReceiver(t *testing.T) {
+	ok, report := ImplementsOf[testImplementsGood, testImplementsPlugin]()
+	if ok {
+		t.Errorf("Implements() = true, want false")
+		return
+	}
+	if !reflect.DeepEqual(report.PointerReceiver, []string{"Stop"}) {
+		t.Errorf("Implements() report.PointerReceiver = %v, want %v", report.PointerReceiver, []string{"Stop"})
+	}
+	expected := "[bvmgo_reflect.testImplementsGood] type does not implement [bvmgo_reflect.testImplementsPlugin] " +
+		"interface: [Stop] method has a pointer receiver, use [*bvmgo_reflect.testImplementsGood] type"
+	if report.String() != expected {
+		t.Errorf("report.String() = [%v], want [%v]", report.String(), expected)
+	}
+}
+
+func TestImplements_missingAndMismatch(t *testing.T) {
+	ok, report := Implements(reflect.TypeOf(testImplementsBad{}), reflect.TypeOf((*testImplementsPlugin)(nil)).Elem())
+	if ok {
+		t.Errorf("Implements() = true, want false")
+		return
+	}
+	if !reflect.DeepEqual(report.Missing, []string{"Stop"}) {
+		t.Errorf("Implements() report.Missing = %v, want %v", report.Missing, []string{"Stop"})
+	}
+	expectedMismatches := []MethodMismatch{{Name: "Start", Expected: "(int) error", Actual: "(string) error"}}
+	if !reflect.DeepEqual(report.Mismatches, expectedMismatches) {
+		t.Errorf("Implements() report.Mismatches = %v, want %v", report.Mismatches, expectedMismatches)
+	}
+	expected := "[bvmgo_reflect.testImplementsBad] type does not implement [bvmgo_reflect.testImplementsPlugin] " +
+		"interface: [Stop] method is missing, [Start] method signature is [(string) error], expected [(int) error]"
+	if report.Err() == nil || report.Err().Error() != expected {
+		t.Errorf("report.Err() = [%v], want [%v]", report.Err(), expected)
+	}
+}
+
+func TestImplements_notInterface(t *testing.T) {
+	ok, report := Implements(reflect.TypeOf(testImplementsBad{}), reflect.TypeOf(0))
+	if ok || !report.NotInterface {
+		t.Errorf("Implements() = %v (%v), want false with not interface report", ok, report)
+	}
+	if report.String() != "[int] type is not an interface" {
+		t.Errorf("report.String() = [%v], want [%v]", report.String(), "[int] type is not an interface")
+	}
+}
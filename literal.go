@@ -0,0 +1,296 @@
+package bvmgo_reflect
+
+import (
+	"fmt"
+	"go/format"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GoLiteralOptions configures GoLiteralWith function.
+type GoLiteralOptions struct {
+	// Package is the import path of the package where the literal is used:
+	// its types are not qualified and their unexported fields are rendered.
+	Package string
+	// ImportAliases maps import paths to their alias.
+	ImportAliases map[string]string
+}
+
+// GoLiteral function returns the Go source of an expression reconstructing a value.
+//
+// Structures are composite literals with field names (zero fields are omitted), pointers are "&T{...}",
+// maps are literals with sorted keys and types are qualified with their package name.
+// Values that cannot be reconstructed (functions, channels, cycles) are rendered as nil with a comment.
+// Use GoLiteralWith function to get imports required by the expression.
+func GoLiteral(value any) string {
+	literal, _ := GoLiteralWith(value, GoLiteralOptions{})
+	return literal
+}
+
+// GoLiteralWith function returns the Go source of an expression reconstructing a value with options,
+// and the sorted import paths required by the expression.
+//
+// See GoLiteral function.
+func GoLiteralWith(value any, options GoLiteralOptions) (literal string, imports []string) {
+	generator := literalGenerator{
+		options:  options,
+		imports:  make(map[string]bool),
+		visiting: make(map[visitedPointer]bool),
+	}
+	generator.typeFormatter = typeNameFormatter{
+		options: TypeNameOptions{Format: TypeNameGoSource, LocalPackage: options.Package,
+			ImportAliases: options.ImportAliases},
+		onImport: func(importPath string) {
+			generator.imports[importPath] = true
+		},
+	}
+	generator.writeValue(reflect.ValueOf(value), true)
+	literal = generator.builder.String()
+	// Format expression with gofmt rules
+	const prefix = "var _ = "
+	if formatted, err := format.Source([]byte(prefix + literal)); err == nil {
+		literal = strings.TrimPrefix(string(formatted), prefix)
+	}
+	for importPath := range generator.imports {
+		imports = append(imports, importPath)
+	}
+	slices.Sort(imports)
+	return
+}
+
+// literalGenerator writes Go expressions in a builder.
+type literalGenerator struct {
+	options       GoLiteralOptions
+	typeFormatter typeNameFormatter
+	builder       strings.Builder
+	imports       map[string]bool
+	visiting      map[visitedPointer]bool
+}
+
+// enter function marks a pointer, a map or a slice as being written, or writes a nil cycle marker
+// and returns false if it is already being written.
+func (generator *literalGenerator) enter(value reflect.Value, typed bool) bool {
+	key := visitedPointer{pointer: value.Pointer(), pointerType: value.Type()}
+	if generator.visiting[key] {
+		generator.writeNil(value.Type(), typed)
+		generator.builder.WriteString(" /* cycle */")
+		return false
+	}
+	generator.visiting[key] = true
+	return true
+}
+
+// leave function unmarks a pointer, a map or a slice written by enter function.
+func (generator *literalGenerator) leave(value reflect.Value) {
+	delete(generator.visiting, visitedPointer{pointer: value.Pointer(), pointerType: value.Type()})
+}
+
+// typeExpression function returns the Go source of a type and registers its imports.
+func (generator *literalGenerator) typeExpression(valueType reflect.Type) string {
+	return generator.typeFormatter.format(valueType)
+}
+
+// writeValue function writes the expression of value.
+//
+// typed is true if the expression type is not given by its context (interface, top level):
+// constants are converted to their type and nil values are typed.
+func (generator *literalGenerator) writeValue(value reflect.Value, typed bool) {
+	if !value.IsValid() {
+		generator.builder.WriteString("nil")
+		return
+	}
+	valueType := value.Type()
+	if valueType == timeType && value.CanInterface() {
+		generator.writeTime(value.Interface().(time.Time))
+		return
+	}
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			generator.builder.WriteString("nil")
+			return
+		}
+		generator.writeValue(value.Elem(), true)
+	case reflect.Ptr:
+		generator.writePointer(value, typed)
+	case reflect.Struct:
+		generator.writeStruct(value)
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			generator.writeNil(valueType, typed)
+			return
+		}
+		if value.Kind() == reflect.Slice && value.Len() > 0 {
+			if !generator.enter(value, typed) {
+				return
+			}
+			defer generator.leave(value)
+		}
+		generator.builder.WriteString(generator.typeExpression(valueType))
+		generator.builder.WriteString("{")
+		for index := 0; index < value.Len(); index++ {
+			generator.builder.WriteString("\n")
+			generator.writeValue(value.Index(index), false)
+			generator.builder.WriteString(",")
+		}
+		if value.Len() > 0 {
+			generator.builder.WriteString("\n")
+		}
+		generator.builder.WriteString("}")
+	case reflect.Map:
+		if value.IsNil() {
+			generator.writeNil(valueType, typed)
+			return
+		}
+		if !generator.enter(value, typed) {
+			return
+		}
+		defer generator.leave(value)
+		generator.builder.WriteString(generator.typeExpression(valueType))
+		generator.builder.WriteString("{")
+		keys := value.MapKeys()
+		sortValues(keys)
+		for _, key := range keys {
+			generator.builder.WriteString("\n")
+			generator.writeValue(key, false)
+			generator.builder.WriteString(": ")
+			generator.writeValue(value.MapIndex(key), false)
+			generator.builder.WriteString(",")
+		}
+		if len(keys) > 0 {
+			generator.builder.WriteString("\n")
+		}
+		generator.builder.WriteString("}")
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		generator.writeNil(valueType, typed)
+		if !value.IsNil() {
+			fmt.Fprintf(&generator.builder, " /* %s value is not reproducible */", typeName(valueType))
+		}
+	default:
+		generator.writeConstant(value, typed)
+	}
+}
+
+// writeNil function writes a nil value, converted to its type if typed.
+func (generator *literalGenerator) writeNil(valueType reflect.Type, typed bool) {
+	if typed {
+		fmt.Fprintf(&generator.builder, "(%s)(nil)", generator.typeExpression(valueType))
+		return
+	}
+	generator.builder.WriteString("nil")
+}
+
+// writePointer function writes a pointer: "&T{...}" for composite values, a function call for others.
+func (generator *literalGenerator) writePointer(value reflect.Value, typed bool) {
+	if value.IsNil() {
+		generator.writeNil(value.Type(), typed)
+		return
+	}
+	if !generator.enter(value, typed) {
+		return
+	}
+	defer generator.leave(value)
+	elem := value.Elem()
+	isComposite := (elem.Kind() == reflect.Struct && elem.Type() != timeType) || elem.Kind() == reflect.Array ||
+		((elem.Kind() == reflect.Slice || elem.Kind() == reflect.Map) && !elem.IsNil())
+	if isComposite {
+		generator.builder.WriteString("&")
+		generator.writeValue(elem, true)
+		return
+	}
+	// Address of a constant cannot be taken: use a function literal,
+	// the variable is declared with its type (interfaces, untyped nil)
+	elemType := generator.typeExpression(elem.Type())
+	fmt.Fprintf(&generator.builder, "func() *%s { var value %s = ", elemType, elemType)
+	generator.writeValue(elem, false)
+	generator.builder.WriteString("; return &value }()")
+}
+
+// writeStruct function writes a structure composite literal with field names.
+func (generator *literalGenerator) writeStruct(value reflect.Value) {
+	valueType := value.Type()
+	generator.builder.WriteString(generator.typeExpression(valueType))
+	generator.builder.WriteString("{")
+	written := false
+	for index := 0; index < valueType.NumField(); index++ {
+		field := valueType.Field(index)
+		fieldValue := value.Field(index)
+		if fieldValue.IsZero() {
+			continue
+		}
+		// Unexported fields are only accessible from their package
+		if !field.IsExported() && valueType.PkgPath() != generator.options.Package {
+			continue
+		}
+		generator.builder.WriteString("\n")
+		generator.builder.WriteString(field.Name)
+		generator.builder.WriteString(": ")
+		generator.writeValue(fieldValue, false)
+		generator.builder.WriteString(",")
+		written = true
+	}
+	if written {
+		generator.builder.WriteString("\n")
+	}
+	generator.builder.WriteString("}")
+}
+
+// writeConstant function writes a boolean, number or string constant.
+func (generator *literalGenerator) writeConstant(value reflect.Value, typed bool) {
+	valueType := value.Type()
+	var literal string
+	switch value.Kind() {
+	case reflect.String:
+		literal = strconv.Quote(value.String())
+	case reflect.Float32, reflect.Float64:
+		float := value.Float()
+		switch {
+		case math.IsNaN(float):
+			generator.imports["math"] = true
+			literal = "math.NaN()"
+		case math.IsInf(float, 1):
+			generator.imports["math"] = true
+			literal = "math.Inf(1)"
+		case math.IsInf(float, -1):
+			generator.imports["math"] = true
+			literal = "math.Inf(-1)"
+		default:
+			literal = strconv.FormatFloat(float, 'g', -1, valueType.Bits())
+			if !strings.ContainsAny(literal, ".e") {
+				literal += ".0"
+			}
+		}
+	default:
+		literal, _ = formatScalar(value)
+	}
+	// Untyped constants default types: no conversion required
+	defaultType := map[reflect.Kind]bool{reflect.Bool: true, reflect.Int: true, reflect.Float64: true,
+		reflect.String: true, reflect.Complex128: true}
+	if typed && !(defaultType[valueType.Kind()] && len(valueType.PkgPath()) == 0) {
+		fmt.Fprintf(&generator.builder, "%s(%s)", generator.typeExpression(valueType), literal)
+		return
+	}
+	generator.builder.WriteString(literal)
+}
+
+// writeTime function writes a time.Time as a time.Date call.
+func (generator *literalGenerator) writeTime(value time.Time) {
+	generator.imports["time"] = true
+	var location string
+	switch value.Location() {
+	case time.UTC:
+		location = "time.UTC"
+	case time.Local:
+		location = "time.Local"
+	default:
+		name, offset := value.Zone()
+		location = fmt.Sprintf("time.FixedZone(%q, %d)", name, offset)
+	}
+	fmt.Fprintf(&generator.builder, "time.Date(%d, time.%s, %d, %d, %d, %d, %d, %s)",
+		value.Year(), value.Month(), value.Day(), value.Hour(), value.Minute(), value.Second(),
+		value.Nanosecond(), location)
+}
@@ -0,0 +1,124 @@
+package bvmgo_reflect
+
+import (
+	"go/parser"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testLiteralLevel int
+
+type testLiteralItem struct {
+	Name  string
+	Level testLiteralLevel
+	Ratio float64
+	Next  *testLiteralItem
+	Count *int
+	Extra any
+	key   string
+}
+
+func TestGoLiteral(t *testing.T) {
+	count := 3
+	var number any = 5
+	var empty any
+	tests := []struct {
+		name string
+		arg  any
+		want string
+	}{
+		{name: "nil", arg: nil, want: "nil"},
+		{name: "int", arg: 12, want: "12"},
+		{name: "int64", arg: int64(12), want: "int64(12)"},
+		{name: "float", arg: 2.0, want: "2.0"},
+		{name: "float32", arg: float32(1.5), want: "float32(1.5)"},
+		{name: "string", arg: "a\"b", want: `"a\"b"`},
+		{name: "named", arg: testLiteralLevel(4), want: "bvmgo_reflect.testLiteralLevel(4)"},
+		{name: "nil slice", arg: []int(nil), want: "([]int)(nil)"},
+		{name: "slice", arg: []uint8{1, 2}, want: "[]uint8{\n\t1,\n\t2,\n}"},
+		{name: "map", arg: map[string]any{"b": int32(2), "a": "x"},
+			want: "map[string]any{\n\t\"a\": \"x\",\n\t\"b\": int32(2),\n}"},
+		{name: "pointer to int", arg: &count, want: "func() *int { var value int = 3; return &value }()"},
+		{name: "pointer to interface", arg: &number, want: "func() *any { var value any = 5; return &value }()"},
+		{name: "pointer to nil interface", arg: &empty, want: "func() *any { var value any = nil; return &value }()"},
+		{name: "time", arg: time.Date(2024, 2, 3, 4, 5, 6, 7, time.UTC),
+			want: "time.Date(2024, time.February, 3, 4, 5, 6, 7, time.UTC)"},
+		{name: "NaN", arg: math.NaN(), want: "math.NaN()"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GoLiteral(tt.arg); got != tt.want {
+				t.Errorf("GoLiteral() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoLiteralWith_struct(t *testing.T) {
+	count := 1
+	item := &testLiteralItem{
+		Name:  "first",
+		Level: 2,
+		Next:  &testLiteralItem{Name: "second", Extra: []string{"x"}},
+		Count: &count,
+		key:   "private",
+	}
+	literal, imports := GoLiteralWith(item, GoLiteralOptions{})
+	expected := `&bvmgo_reflect.testLiteralItem{
+	Name:  "first",
+	Level: 2,
+	Next: &bvmgo_reflect.testLiteralItem{
+		Name: "second",
+		Extra: []string{
+			"x",
+		},
+	},
+	Count: func() *int { var value int = 1; return &value }(),
+}`
+	if literal != expected {
+		t.Errorf("GoLiteralWith(...) = [%v], want [%v]", literal, expected)
+	}
+	if !reflect.DeepEqual(imports, []string{"bvmgo-reflect"}) {
+		t.Errorf("GoLiteralWith(...) imports = %v, want %v", imports, []string{"bvmgo-reflect"})
+	}
+	if _, err := parser.ParseExpr(literal); err != nil {
+		t.Errorf("parser.ParseExpr(...) returns \"%v\" error, want no error", err)
+	}
+}
+
+func TestGoLiteralWith_localPackage(t *testing.T) {
+	item := testLiteralItem{Name: "local", key: "private"}
+	literal, imports := GoLiteralWith(item, GoLiteralOptions{Package: "bvmgo-reflect"})
+	expected := "testLiteralItem{\n\tName: \"local\",\n\tkey:  \"private\",\n}"
+	if literal != expected {
+		t.Errorf("GoLiteralWith(...) = [%v], want [%v]", literal, expected)
+	}
+	if len(imports) != 0 {
+		t.Errorf("GoLiteralWith(...) imports = %v, want no import", imports)
+	}
+}
+
+func TestGoLiteral_cycle(t *testing.T) {
+	item := &testLiteralItem{Name: "loop"}
+	item.Next = item
+	literal := GoLiteral(item)
+	if _, err := parser.ParseExpr(literal); err != nil {
+		t.Errorf("parser.ParseExpr(%s) returns \"%v\" error, want no error", literal, err)
+	}
+}
+
+func TestGoLiteral_mapAndSliceCycles(t *testing.T) {
+	values := map[string]any{"name": "loop"}
+	values["self"] = values
+	list := []any{"loop", nil}
+	list[1] = list
+	for _, value := range []any{values, list} {
+		literal := GoLiteral(value)
+		if _, err := parser.ParseExpr(literal); err != nil || !strings.Contains(literal, "/* cycle */") {
+			t.Errorf("GoLiteral(...) = %s (parse error \"%v\"), want a nil cycle marker", literal, err)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package bvmgo_reflect
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// errorType is the reflect.Type of error interface.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// CallMethod function calls the "methodName" method of target with arguments and returns its results.
+//
+// The method is searched on target and on its pointer (a copy of target is used if target is not a pointer).
+// Arguments are converted like SetValue function values (nil is the zero value, pointers and values are adapted),
+// variadic arguments are supported (the last argument may also be a slice of variadic type).
+// If the last result of the method is an error, it is removed from results and returned as CallMethod error.
+//
+// CallMethod function returns an error if:
+//   - target is nil or invalid,
+//   - methodName is not a valid method name,
+//   - methodName is not found on target,
+//   - arguments count does not match method parameters,
+//   - an argument type is incompatible with method parameter type,
+//   - method returns a not nil error.
+func CallMethod(target any, methodName string, arguments ...any) ([]any, error) {
+	method, targetType, err := findMethod(target, methodName)
+	if err != nil {
+		return nil, err
+	}
+	cleanMethodName := strings.TrimSpace(methodName)
+	methodType := method.Type()
+	// Check arguments count
+	fixedCount := methodType.NumIn()
+	if methodType.IsVariadic() {
+		fixedCount--
+		if len(arguments) < fixedCount {
+			return nil, fmt.Errorf("[%s.%s] method requires at least %d arguments, got %d",
+				typeName(targetType), cleanMethodName, fixedCount, len(arguments))
+		}
+	} else if len(arguments) != fixedCount {
+		return nil, fmt.Errorf("[%s.%s] method requires %d arguments, got %d",
+			typeName(targetType), cleanMethodName, fixedCount, len(arguments))
+	}
+	// Last argument may be the variadic slice itself
+	useSlice := false
+	if methodType.IsVariadic() && len(arguments) == methodType.NumIn() {
+		lastArgument := reflect.ValueOf(arguments[len(arguments)-1])
+		useSlice = lastArgument.IsValid() && lastArgument.Type().AssignableTo(methodType.In(fixedCount))
+	}
+	argumentValues := make([]reflect.Value, len(arguments))
+	for index, argument := range arguments {
+		var parameterType reflect.Type
+		if index < fixedCount || useSlice {
+			parameterType = methodType.In(index)
+		} else {
+			parameterType = methodType.In(fixedCount).Elem()
+		}
+		argumentValue := reflect.New(parameterType).Elem()
+		if err := setValueToReflectValue(argumentValue, argument); err != nil {
+			return nil, fmt.Errorf("[%s.%s] method argument [%d] cannot be set with current value: %w",
+				typeName(targetType), cleanMethodName, index, err)
+		}
+		argumentValues[index] = argumentValue
+	}
+	var resultValues []reflect.Value
+	if useSlice {
+		resultValues = method.CallSlice(argumentValues)
+	} else {
+		resultValues = method.Call(argumentValues)
+	}
+	return unpackResults(methodType, resultValues)
+}
+
+// findMethod function returns the "methodName" method of target (or of its pointer) and target type.
+//
+// findMethod function returns an error if:
+//   - target is nil or invalid,
+//   - methodName is not a valid method name,
+//   - methodName is not found on target.
+func findMethod(target any, methodName string) (method reflect.Value, targetType reflect.Type, err error) {
+	cleanMethodName := strings.TrimSpace(methodName)
+	targetValue := reflect.ValueOf(target)
+	// Check is not null
+	if !targetValue.IsValid() || (targetValue.Kind() == reflect.Ptr && targetValue.IsNil()) {
+		err = fmt.Errorf("a not nil value is required to call [%s] method", cleanMethodName)
+		return
+	}
+	targetType = targetValue.Type()
+	// Check method name
+	if len(cleanMethodName) == 0 {
+		err = fmt.Errorf("method name is empty")
+		return
+	}
+	method = targetValue.MethodByName(cleanMethodName)
+	if !method.IsValid() && targetValue.Kind() != reflect.Ptr {
+		// Method with pointer receiver: call it on a copy
+		pointer := reflect.New(targetType)
+		pointer.Elem().Set(targetValue)
+		method = pointer.MethodByName(cleanMethodName)
+	}
+	if !method.IsValid() {
+		err = fmt.Errorf("[%s.%s] method is not found", typeName(targetType), cleanMethodName)
+		return
+	}
+	return
+}
+
+// unpackResults function returns results as values, the last error result is returned as error.
+func unpackResults(functionType reflect.Type, resultValues []reflect.Value) ([]any, error) {
+	var err error
+	if count := functionType.NumOut(); count > 0 && functionType.Out(count-1) == errorType {
+		if errorValue := resultValues[count-1]; !errorValue.IsNil() {
+			err = errorValue.Interface().(error)
+		}
+		resultValues = resultValues[:count-1]
+	}
+	results := make([]any, len(resultValues))
+	for index, resultValue := range resultValues {
+		results[index] = resultValue.Interface()
+	}
+	return results, err
+}
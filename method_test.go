@@ -0,0 +1,115 @@
+package bvmgo_reflect
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testMethodStruct struct {
+	Counter int
+}
+
+func (test testMethodStruct) Add(a int, b int) int {
+	return a + b
+}
+
+func (test testMethodStruct) Join(separator string, values ...string) string {
+	return strings.Join(values, separator)
+}
+
+func (test *testMethodStruct) Increment(step *int) int {
+	test.Counter += *step
+	return test.Counter
+}
+
+func (test *testMethodStruct) Divide(a float64, b float64) (float64, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a / b, nil
+}
+
+func (test *testMethodStruct) Describe(sub testSetSubStruct) (string, int) {
+	return "sub", sub.Field1
+}
+
+func TestCallMethod(t *testing.T) {
+	target := &testMethodStruct{Counter: 1}
+	tests := []struct {
+		name       string
+		methodName string
+		arguments  []any
+		want       []any
+	}{
+		{name: "value receiver", methodName: "Add", arguments: []any{1, 2}, want: []any{3}},
+		{name: "variadic", methodName: "Join", arguments: []any{"-", "a", "b", "c"}, want: []any{"a-b-c"}},
+		{name: "variadic empty", methodName: "Join", arguments: []any{"-"}, want: []any{""}},
+		{name: "variadic slice", methodName: "Join", arguments: []any{"+", []string{"x", "y"}}, want: []any{"x+y"}},
+		{name: "value to pointer argument", methodName: "Increment", arguments: []any{2}, want: []any{3}},
+		{name: "pointer to value argument", methodName: "Describe", arguments: []any{&testSetSubStruct{Field1: 7}},
+			want: []any{"sub", 7}},
+		{name: "error unpacked", methodName: " Divide ", arguments: []any{6.0, 3.0}, want: []any{2.0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CallMethod(target, tt.methodName, tt.arguments...)
+			if err != nil {
+				t.Errorf("CallMethod() error = %v, want no Error", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CallMethod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallMethod_pointerMethodOnValue(t *testing.T) {
+	target := testMethodStruct{Counter: 10}
+	got, err := CallMethod(target, "Increment", 5)
+	if err != nil {
+		t.Errorf("CallMethod() error = %v, want no Error", err)
+		return
+	}
+	if !reflect.DeepEqual(got, []any{15}) || target.Counter != 10 {
+		t.Errorf("CallMethod() = %v (counter %d), want %v (counter 10)", got, target.Counter, []any{15})
+	}
+}
+
+func TestCallMethod_errors(t *testing.T) {
+	target := &testMethodStruct{}
+	tests := []struct {
+		name       string
+		target     any
+		methodName string
+		arguments  []any
+		want       string
+	}{
+		{name: "not found", target: target, methodName: "Unknown",
+			want: "[*bvmgo_reflect.testMethodStruct.Unknown] method is not found"},
+		{name: "arity", target: target, methodName: "Add", arguments: []any{1},
+			want: "[*bvmgo_reflect.testMethodStruct.Add] method requires 2 arguments, got 1"},
+		{name: "variadic arity", target: target, methodName: "Join",
+			want: "method requires at least 1 arguments, got 0"},
+		{name: "bad argument", target: target, methodName: "Add", arguments: []any{1, "two"},
+			want: "[*bvmgo_reflect.testMethodStruct.Add] method argument [1] cannot be set with current value"},
+		{name: "returned error", target: target, methodName: "Divide", arguments: []any{1.0, 0.0},
+			want: "division by zero"},
+		{name: "nil target", target: nil, methodName: "Add", want: "a not nil value is required"},
+		{name: "empty name", target: target, methodName: " ", want: "method name is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CallMethod(tt.target, tt.methodName, tt.arguments...)
+			if err == nil {
+				t.Errorf("CallMethod(...) returns nil (no error), want an error")
+				return
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,540 @@
+package bvmgo_reflect
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultDiscriminator is the JSON key holding the registered type name of polymorphic values.
+const DefaultDiscriminator = "type"
+
+// PolymorphicCodec encodes and decodes JSON with interface-typed values (for instance a Shape interface
+// implemented by Circle and Square structures) using a discriminator key and a type registry.
+type PolymorphicCodec struct {
+	registry      *TypeRegistry
+	discriminator string
+}
+
+// jsonMarshalerType is the reflect.Type of json.Marshaler interface.
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// jsonUnmarshalerType is the reflect.Type of json.Unmarshaler interface.
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// encodeInterfaceTypes caches if types contain interfaces to encode (reflect.Type -> bool).
+var encodeInterfaceTypes sync.Map
+
+// decodeInterfaceTypes caches if types contain interfaces to decode (reflect.Type -> bool).
+var decodeInterfaceTypes sync.Map
+
+// NewPolymorphicCodec function returns a codec using registry (DefaultTypeRegistry if nil)
+// and discriminator key (DefaultDiscriminator if empty).
+func NewPolymorphicCodec(registry *TypeRegistry, discriminator string) *PolymorphicCodec {
+	if registry == nil {
+		registry = DefaultTypeRegistry
+	}
+	if len(strings.TrimSpace(discriminator)) == 0 {
+		discriminator = DefaultDiscriminator
+	}
+	return &PolymorphicCodec{registry: registry, discriminator: strings.TrimSpace(discriminator)}
+}
+
+// MarshalPolymorphic function returns the JSON encoding of value with the default registry and discriminator.
+//
+// See PolymorphicCodec.Marshal function.
+func MarshalPolymorphic(value any) ([]byte, error) {
+	return NewPolymorphicCodec(nil, "").Marshal(value)
+}
+
+// UnmarshalPolymorphic function decodes JSON data into target with the default registry and discriminator.
+//
+// See PolymorphicCodec.Unmarshal function.
+func UnmarshalPolymorphic(data []byte, target any) error {
+	return NewPolymorphicCodec(nil, "").Unmarshal(data, target)
+}
+
+// Marshal function returns the JSON encoding of value, following encoding/json rules.
+//
+// Values held by interface-typed fields, elements or entries (and value itself if its type is registered)
+// are encoded as JSON objects with the discriminator key set to their registered type name.
+//
+// Marshal function returns an error if:
+//   - the type of an interface-typed value is not registered,
+//   - a registered value is not encoded as a JSON object, or has a property named like the discriminator,
+//   - encoding/json returns an error.
+func (codec *PolymorphicCodec) Marshal(value any) ([]byte, error) {
+	sourceValue := reflect.ValueOf(value)
+	if !sourceValue.IsValid() {
+		return []byte("null"), nil
+	}
+	if codec.nameOf(sourceValue.Type()) != "" {
+		return codec.encodePolymorphic(sourceValue)
+	}
+	return codec.encode(sourceValue)
+}
+
+// Unmarshal function decodes JSON data into target, following encoding/json rules.
+//
+// Interface-typed fields, elements or entries (and target itself if it is a pointer to an interface)
+// are decoded by reading the discriminator key and decoding the object into a new value of the registered type
+// (a pointer to the new value is assigned if it implements the interface, else the value itself).
+// Empty interfaces (any) without discriminator key are decoded by encoding/json.
+//
+// Unmarshal function returns an error if:
+//   - target is not a not nil pointer,
+//   - a discriminator is missing (non-empty interfaces) or not registered,
+//   - a registered type does not implement its interface,
+//   - encoding/json returns an error.
+func (codec *PolymorphicCodec) Unmarshal(data []byte, target any) error {
+	targetValue := reflect.ValueOf(target)
+	// Check is not null
+	if !targetValue.IsValid() || (targetValue.Kind() == reflect.Ptr && targetValue.IsNil()) {
+		return fmt.Errorf("a not nil pointer is required to unmarshal JSON")
+	}
+	// Check is a pointer
+	if targetValue.Kind() != reflect.Ptr {
+		return fmt.Errorf("unsupported type [%s], a pointer is required to unmarshal JSON",
+			typeName(targetValue.Type()))
+	}
+	return codec.decode(data, targetValue.Elem())
+}
+
+// nameOf function returns the registered name of a type (or of its pointed type), empty if not registered.
+func (codec *PolymorphicCodec) nameOf(valueType reflect.Type) string {
+	if name, ok := codec.registry.NameOf(valueType); ok {
+		return name
+	}
+	if valueType.Kind() == reflect.Ptr {
+		if name, ok := codec.registry.NameOf(valueType.Elem()); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// containsInterface function returns true if a value of type may hold interface values
+// to encode (decoding is false) or to decode (decoding is true).
+func containsInterface(valueType reflect.Type, decoding bool) bool {
+	cache := &encodeInterfaceTypes
+	if decoding {
+		cache = &decodeInterfaceTypes
+	}
+	if result, ok := cache.Load(valueType); ok {
+		return result.(bool)
+	}
+	result := containsInterfaceVisiting(valueType, decoding, make(map[reflect.Type]bool))
+	cache.Store(valueType, result)
+	return result
+}
+
+// containsInterfaceVisiting function returns true if a value of type may hold interface values
+// to encode or to decode: types implementing json.Marshaler (encoding) or json.Unmarshaler (decoding)
+// are handled by encoding/json.
+//
+// visiting contains types being computed (recursive types).
+func containsInterfaceVisiting(valueType reflect.Type, decoding bool, visiting map[reflect.Type]bool) bool {
+	if visiting[valueType] {
+		return false
+	}
+	if (!decoding && valueType.Implements(jsonMarshalerType)) ||
+		(decoding && reflect.PointerTo(valueType).Implements(jsonUnmarshalerType)) {
+		return false
+	}
+	visiting[valueType] = true
+	defer delete(visiting, valueType)
+	switch valueType.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return containsInterfaceVisiting(valueType.Elem(), decoding, visiting)
+	case reflect.Struct:
+		for _, field := range jsonFields(valueType) {
+			if containsInterfaceVisiting(field.field.Type, decoding, visiting) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// encode function returns the JSON encoding of value.
+func (codec *PolymorphicCodec) encode(value reflect.Value) (json.RawMessage, error) {
+	if !containsInterface(value.Type(), false) {
+		return json.Marshal(value.Interface())
+	}
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return json.RawMessage("null"), nil
+		}
+		return codec.encodePolymorphic(value.Elem())
+	case reflect.Ptr:
+		if value.IsNil() {
+			return json.RawMessage("null"), nil
+		}
+		return codec.encode(value.Elem())
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return json.RawMessage("null"), nil
+		}
+		var buffer bytes.Buffer
+		buffer.WriteByte('[')
+		for index := 0; index < value.Len(); index++ {
+			if index > 0 {
+				buffer.WriteByte(',')
+			}
+			element, err := codec.encode(value.Index(index))
+			if err != nil {
+				return nil, err
+			}
+			buffer.Write(element)
+		}
+		buffer.WriteByte(']')
+		return buffer.Bytes(), nil
+	case reflect.Map:
+		if value.IsNil() {
+			return json.RawMessage("null"), nil
+		}
+		entries := make(map[string]json.RawMessage, value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			key, err := jsonMapKey(iterator.Key())
+			if err != nil {
+				return nil, err
+			}
+			entry, err := codec.encode(iterator.Value())
+			if err != nil {
+				return nil, err
+			}
+			entries[key] = entry
+		}
+		return json.Marshal(entries)
+	case reflect.Struct:
+		var buffer bytes.Buffer
+		buffer.WriteByte('{')
+		written := false
+		for _, field := range jsonFields(value.Type()) {
+			fieldValue, err := value.FieldByIndexErr(field.index)
+			if err != nil {
+				// Field of a nil embedded pointer
+				continue
+			}
+			if field.omitEmpty && isZeroValue(fieldValue, ZeroOptions{OmitEmpty: true}, nil) {
+				continue
+			}
+			encodedField, err := codec.encode(fieldValue)
+			if err == nil && field.asString && isJSONQuotableType(field.field.Type) && string(encodedField) != "null" {
+				// Option string: scalar JSON value encoded inside a JSON string
+				encodedField, err = json.Marshal(string(encodedField))
+			}
+			if err != nil {
+				return nil, fmt.Errorf("[%s.%s] field cannot be encoded: %w",
+					typeName(value.Type()), field.field.Name, err)
+			}
+			if written {
+				buffer.WriteByte(',')
+			}
+			key, _ := json.Marshal(field.name)
+			buffer.Write(key)
+			buffer.WriteByte(':')
+			buffer.Write(encodedField)
+			written = true
+		}
+		buffer.WriteByte('}')
+		return buffer.Bytes(), nil
+	default:
+		return json.Marshal(value.Interface())
+	}
+}
+
+// encodePolymorphic function returns the JSON object of a registered value with its discriminator.
+func (codec *PolymorphicCodec) encodePolymorphic(value reflect.Value) (json.RawMessage, error) {
+	name := codec.nameOf(value.Type())
+	if len(name) == 0 {
+		return nil, fmt.Errorf("[%s] type is not registered", typeName(value.Type()))
+	}
+	encoded, err := codec.encode(value)
+	if err != nil {
+		return nil, err
+	}
+	encoded = bytes.TrimSpace(encoded)
+	if len(encoded) < 2 || encoded[0] != '{' {
+		return nil, fmt.Errorf("[%s] type is not encoded as a JSON object, discriminator cannot be added",
+			typeName(value.Type()))
+	}
+	var properties map[string]json.RawMessage
+	if err = json.Unmarshal(encoded, &properties); err != nil {
+		return nil, err
+	}
+	if _, exists := properties[codec.discriminator]; exists {
+		return nil, fmt.Errorf("[%s] type has a [%s] property, discriminator cannot be added",
+			typeName(value.Type()), codec.discriminator)
+	}
+	key, _ := json.Marshal(codec.discriminator)
+	discriminatorValue, _ := json.Marshal(name)
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	buffer.Write(key)
+	buffer.WriteByte(':')
+	buffer.Write(discriminatorValue)
+	if len(bytes.TrimSpace(encoded[1:len(encoded)-1])) > 0 {
+		buffer.WriteByte(',')
+	}
+	buffer.Write(encoded[1:])
+	return buffer.Bytes(), nil
+}
+
+// decode function decodes JSON data into target.
+func (codec *PolymorphicCodec) decode(data []byte, target reflect.Value) error {
+	if !containsInterface(target.Type(), true) {
+		return json.Unmarshal(data, target.Addr().Interface())
+	}
+	isNull := bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+	switch target.Kind() {
+	case reflect.Interface:
+		if isNull {
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		}
+		return codec.decodePolymorphic(data, target)
+	case reflect.Ptr:
+		if isNull {
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		}
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return codec.decode(data, target.Elem())
+	case reflect.Slice, reflect.Array:
+		if isNull {
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		}
+		var elements []json.RawMessage
+		if err := json.Unmarshal(data, &elements); err != nil {
+			return err
+		}
+		if target.Kind() == reflect.Slice {
+			target.Set(reflect.MakeSlice(target.Type(), len(elements), len(elements)))
+		}
+		for index, element := range elements {
+			if index >= target.Len() {
+				break
+			}
+			if err := codec.decode(element, target.Index(index)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		if isNull {
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		}
+		var entries map[string]json.RawMessage
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return err
+		}
+		if target.IsNil() {
+			target.Set(reflect.MakeMapWithSize(target.Type(), len(entries)))
+		}
+		for key, entry := range entries {
+			keyValue, err := parseJSONMapKey(key, target.Type().Key())
+			if err != nil {
+				return err
+			}
+			entryValue := reflect.New(target.Type().Elem())
+			if err := codec.decode(entry, entryValue.Elem()); err != nil {
+				return err
+			}
+			target.SetMapIndex(keyValue, entryValue.Elem())
+		}
+		return nil
+	case reflect.Struct:
+		if isNull {
+			return nil
+		}
+		var properties map[string]json.RawMessage
+		if err := json.Unmarshal(data, &properties); err != nil {
+			return err
+		}
+		fields := jsonFields(target.Type())
+		for key, property := range properties {
+			field := findJSONField(fields, key)
+			if field == nil {
+				continue
+			}
+			fieldValue, err := fieldByIndexAlloc(target, field.index)
+			if err == nil && field.asString && isJSONQuotableType(field.field.Type) &&
+				!bytes.Equal(bytes.TrimSpace(property), []byte("null")) {
+				// Option string: scalar JSON value decoded from a JSON string
+				var quoted string
+				if err = json.Unmarshal(property, &quoted); err == nil {
+					property = json.RawMessage(quoted)
+				}
+			}
+			if err == nil {
+				err = codec.decode(property, fieldValue)
+			}
+			if err != nil {
+				return fmt.Errorf("[%s.%s] field cannot be decoded: %w",
+					typeName(target.Type()), field.field.Name, err)
+			}
+		}
+		return nil
+	default:
+		return json.Unmarshal(data, target.Addr().Interface())
+	}
+}
+
+// decodePolymorphic function decodes a JSON object into an interface target using its discriminator.
+func (codec *PolymorphicCodec) decodePolymorphic(data []byte, target reflect.Value) error {
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil || properties[codec.discriminator] == nil {
+		if target.NumMethod() == 0 {
+			// Empty interface without discriminator: default decoding
+			return json.Unmarshal(data, target.Addr().Interface())
+		}
+		return fmt.Errorf("[%s] discriminator is required to decode [%s] interface",
+			codec.discriminator, typeName(target.Type()))
+	}
+	var name string
+	if err := json.Unmarshal(properties[codec.discriminator], &name); err != nil {
+		return fmt.Errorf("[%s] discriminator is not a string: %w", codec.discriminator, err)
+	}
+	registeredType, ok := codec.registry.Lookup(name)
+	if !ok {
+		return fmt.Errorf("[%s] type name is not registered", name)
+	}
+	// The discriminator is not a property of the concrete value
+	delete(properties, codec.discriminator)
+	concreteData, err := json.Marshal(properties)
+	if err != nil {
+		return err
+	}
+	concrete := reflect.New(registeredType)
+	if err := codec.decode(concreteData, concrete.Elem()); err != nil {
+		return err
+	}
+	if concrete.Type().AssignableTo(target.Type()) {
+		target.Set(concrete)
+	} else if registeredType.AssignableTo(target.Type()) {
+		target.Set(concrete.Elem())
+	} else {
+		return fmt.Errorf("[%s] type does not implement [%s] interface",
+			typeName(registeredType), typeName(target.Type()))
+	}
+	return nil
+}
+
+// findJSONField function returns the field matching a JSON key (exact match first, then case-insensitive).
+func findJSONField(fields []jsonField, key string) *jsonField {
+	for index := range fields {
+		if fields[index].name == key {
+			return &fields[index]
+		}
+	}
+	for index := range fields {
+		if strings.EqualFold(fields[index].name, key) {
+			return &fields[index]
+		}
+	}
+	return nil
+}
+
+// fieldByIndexAlloc function returns the field at index path, allocating nil embedded structure pointers.
+func fieldByIndexAlloc(structValue reflect.Value, index []int) (reflect.Value, error) {
+	current := structValue
+	for position, fieldIndex := range index {
+		if position > 0 && current.Kind() == reflect.Ptr {
+			if current.IsNil() {
+				if !current.CanSet() {
+					return reflect.Value{}, fmt.Errorf("[%s] embedded pointer is not settable", typeName(current.Type()))
+				}
+				current.Set(reflect.New(current.Type().Elem()))
+			}
+			current = current.Elem()
+		}
+		current = current.Field(fieldIndex)
+	}
+	return current, nil
+}
+
+// isJSONQuotableType function checks if the string option of encoding/json applies to a field type:
+// strings, numbers and booleans, or unnamed pointers to them.
+func isJSONQuotableType(fieldType reflect.Type) bool {
+	if fieldType.Name() == "" && fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// jsonMapKey function returns the JSON object key of a map key, following encoding/json rules:
+// strings are used as is, encoding.TextMarshaler keys are marshaled and integers are formatted.
+func jsonMapKey(key reflect.Value) (string, error) {
+	if key.Kind() == reflect.String {
+		return key.String(), nil
+	}
+	if key.Type().Implements(textMarshalerType) {
+		if key.Kind() == reflect.Ptr && key.IsNil() {
+			return "", nil
+		}
+		text, err := key.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("unsupported type [%s], map key cannot be encoded", typeName(key.Type()))
+	}
+}
+
+// parseJSONMapKey function returns the map key of keyType matching a JSON object key, following encoding/json rules:
+// encoding.TextUnmarshaler keys are unmarshaled, strings are used as is and integers are parsed.
+func parseJSONMapKey(key string, keyType reflect.Type) (reflect.Value, error) {
+	if reflect.PointerTo(keyType).Implements(textUnmarshalerType) {
+		keyValue := reflect.New(keyType)
+		if err := keyValue.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(key)); err != nil {
+			return reflect.Value{}, err
+		}
+		return keyValue.Elem(), nil
+	}
+	keyValue := reflect.New(keyType).Elem()
+	switch keyType.Kind() {
+	case reflect.String:
+		keyValue.SetString(key)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := strconv.ParseInt(key, 10, 64)
+		if err != nil || keyValue.OverflowInt(number) {
+			return reflect.Value{}, fmt.Errorf("[%s] key cannot be decoded as [%s]", key, typeName(keyType))
+		}
+		keyValue.SetInt(number)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		number, err := strconv.ParseUint(key, 10, 64)
+		if err != nil || keyValue.OverflowUint(number) {
+			return reflect.Value{}, fmt.Errorf("[%s] key cannot be decoded as [%s]", key, typeName(keyType))
+		}
+		keyValue.SetUint(number)
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type [%s], map key cannot be decoded", typeName(keyType))
+	}
+	return keyValue, nil
+}
@@ -0,0 +1,265 @@
+package bvmgo_reflect
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testShape interface {
+	Area() float64
+}
+
+type testCircle struct {
+	Radius float64 `json:"radius"`
+}
+
+func (circle testCircle) Area() float64 { return 3 * circle.Radius * circle.Radius }
+
+type testSquare struct {
+	Side float64 `json:"side"`
+}
+
+func (square *testSquare) Area() float64 { return square.Side * square.Side }
+
+type testDrawing struct {
+	Name    string               `json:"name"`
+	Main    testShape            `json:"main"`
+	Shapes  []testShape          `json:"shapes"`
+	ByName  map[string]testShape `json:"byName,omitempty"`
+	Extra   any                  `json:"extra,omitempty"`
+	Missing testShape            `json:"missing"`
+}
+
+type testJSONRules struct {
+	Count   int       `json:"count,string"`
+	Name    string    `json:"name,string"`
+	Ratio   *float64  `json:"ratio,string"`
+	Missing *int      `json:"missing,string"`
+	Created time.Time `json:"created,omitempty"`
+	Shape   testShape `json:"shape,omitempty"`
+}
+
+// testLabel has a property whose name differs from the "kind" discriminator by case only.
+type testLabel struct {
+	Kind string
+}
+
+func (label testLabel) Area() float64 { return 0 }
+
+// testKindLabel has a property colliding with the "kind" discriminator.
+type testKindLabel struct {
+	Kind string `json:"kind"`
+}
+
+func (label testKindLabel) Area() float64 { return 0 }
+
+// testShapeHolder only defines a custom JSON decoding.
+type testShapeHolder struct {
+	Shape testShape `json:"shape"`
+}
+
+func (holder *testShapeHolder) UnmarshalJSON(data []byte) error {
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return err
+	}
+	return testPolymorphicCodec().Unmarshal(properties["shape"], &holder.Shape)
+}
+
+func testPolymorphicCodec() *PolymorphicCodec {
+	registry := NewTypeRegistry()
+	_ = registry.RegisterType(reflect.TypeOf(testCircle{}), "circle")
+	_ = registry.RegisterType(reflect.TypeOf(testSquare{}), "square")
+	_ = registry.RegisterType(reflect.TypeOf(testLabel{}), "label")
+	_ = registry.RegisterType(reflect.TypeOf(testKindLabel{}), "kindLabel")
+	return NewPolymorphicCodec(registry, "kind")
+}
+
+func TestPolymorphicCodec_marshal(t *testing.T) {
+	drawing := testDrawing{
+		Name:   "drawing",
+		Main:   testCircle{Radius: 1},
+		Shapes: []testShape{&testSquare{Side: 2}, testCircle{Radius: 3}},
+		ByName: map[string]testShape{"c": testCircle{Radius: 4}},
+	}
+	data, err := testPolymorphicCodec().Marshal(drawing)
+	if err != nil {
+		t.Errorf("Marshal(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	expected := `{"name":"drawing","main":{"kind":"circle","radius":1},` +
+		`"shapes":[{"kind":"square","side":2},{"kind":"circle","radius":3}],` +
+		`"byName":{"c":{"kind":"circle","radius":4}},"missing":null}`
+	if string(data) != expected {
+		t.Errorf("Marshal(...) = [%s], want [%s]", data, expected)
+	}
+}
+
+func TestPolymorphicCodec_unmarshal(t *testing.T) {
+	data := `{"name":"drawing","main":{"kind":"circle","radius":1},` +
+		`"shapes":[{"kind":"square","side":2},{"radius":3,"kind":"circle"}],` +
+		`"byName":{"c":{"kind":"circle","radius":4}},"extra":{"a":1},"missing":null}`
+	var drawing testDrawing
+	if err := testPolymorphicCodec().Unmarshal([]byte(data), &drawing); err != nil {
+		t.Errorf("Unmarshal(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	expected := testDrawing{
+		Name:   "drawing",
+		Main:   &testCircle{Radius: 1},
+		Shapes: []testShape{&testSquare{Side: 2}, &testCircle{Radius: 3}},
+		ByName: map[string]testShape{"c": &testCircle{Radius: 4}},
+		Extra:  map[string]any{"a": 1.0},
+	}
+	if report := Diff(drawing, expected); !report.Equal() {
+		t.Errorf("Unmarshal(...) differs from expected:\n%s", report)
+	}
+}
+
+func TestPolymorphicCodec_roundTripInterface(t *testing.T) {
+	codec := testPolymorphicCodec()
+	var shape testShape = &testSquare{Side: 5}
+	data, err := codec.Marshal(shape)
+	if err != nil {
+		t.Errorf("Marshal(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	var decoded testShape
+	if err := codec.Unmarshal(data, &decoded); err != nil {
+		t.Errorf("Unmarshal(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if decoded.Area() != 25 {
+		t.Errorf("decoded.Area() = %v, want %v", decoded.Area(), 25)
+	}
+}
+
+func TestPolymorphicCodec_encodingJSONRules(t *testing.T) {
+	ratio := 0.5
+	rules := testJSONRules{Count: 5, Name: "name", Ratio: &ratio}
+	data, err := testPolymorphicCodec().Marshal(rules)
+	if err != nil {
+		t.Errorf("Marshal(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	expected, _ := json.Marshal(rules)
+	if string(data) != string(expected) {
+		t.Errorf("Marshal(...) = [%s], want [%s]", data, expected)
+	}
+	var decoded testJSONRules
+	if err := testPolymorphicCodec().Unmarshal(expected, &decoded); err != nil {
+		t.Errorf("Unmarshal(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if report := Diff(decoded, rules); !report.Equal() {
+		t.Errorf("Unmarshal(...) differs from expected:\n%s", report)
+	}
+}
+
+func TestPolymorphicCodec_mapKeys(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{map[string]testShape{`a"b\c`: testCircle{Radius: 1}}, `{"a\"b\\c":{"kind":"circle","radius":1}}`},
+		{map[int]testShape{-1: testCircle{Radius: 1}}, `{"-1":{"kind":"circle","radius":1}}`},
+		{map[uint8]testShape{2: testCircle{Radius: 1}}, `{"2":{"kind":"circle","radius":1}}`},
+	}
+	codec := testPolymorphicCodec()
+	for _, test := range tests {
+		data, err := codec.Marshal(test.value)
+		if err != nil {
+			t.Errorf("Marshal(%v) returns \"%v\" error, want no error", test.value, err)
+			continue
+		}
+		if string(data) != test.expected {
+			t.Errorf("Marshal(%v) = [%s], want [%s]", test.value, data, test.expected)
+		}
+		decoded := reflect.New(reflect.TypeOf(test.value))
+		if err := codec.Unmarshal(data, decoded.Interface()); err != nil {
+			t.Errorf("Unmarshal([%s]) returns \"%v\" error, want no error", data, err)
+			continue
+		}
+		decodedKeys := decoded.Elem().MapKeys()
+		expectedKeys := reflect.ValueOf(test.value).MapKeys()
+		if len(decodedKeys) != 1 || decodedKeys[0].Interface() != expectedKeys[0].Interface() {
+			t.Errorf("Unmarshal([%s]) keys = %v, want %v", data, decodedKeys, expectedKeys)
+		}
+	}
+}
+
+func TestPolymorphicCodec_unmarshalerOnly(t *testing.T) {
+	codec := testPolymorphicCodec()
+	holder := testShapeHolder{Shape: testCircle{Radius: 2}}
+	data, err := codec.Marshal(holder)
+	if err != nil {
+		t.Errorf("Marshal(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	expected := `{"shape":{"kind":"circle","radius":2}}`
+	if string(data) != expected {
+		t.Errorf("Marshal(...) = [%s], want [%s]", data, expected)
+	}
+	var decoded testShapeHolder
+	if err := codec.Unmarshal(data, &decoded); err != nil {
+		t.Errorf("Unmarshal(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if report := Diff(decoded, testShapeHolder{Shape: &testCircle{Radius: 2}}); !report.Equal() {
+		t.Errorf("Unmarshal(...) differs from expected:\n%s", report)
+	}
+}
+
+func TestPolymorphicCodec_discriminatorProperty(t *testing.T) {
+	codec := testPolymorphicCodec()
+	for attempt := 0; attempt < 20; attempt++ {
+		var shape testShape
+		if err := codec.Unmarshal([]byte(`{"kind":"label","Kind":"name"}`), &shape); err != nil {
+			t.Errorf("Unmarshal(...) returns \"%v\" error, want no error", err)
+			return
+		}
+		if label, ok := shape.(*testLabel); !ok || label.Kind != "name" {
+			t.Errorf("Unmarshal(...) = %#v, want &testLabel{Kind: \"name\"}", shape)
+			return
+		}
+	}
+	_, err := codec.Marshal(testKindLabel{Kind: "name"})
+	if err == nil || !strings.Contains(err.Error(), "type has a [kind] property, discriminator cannot be added") {
+		t.Errorf("Marshal(...) error = [%v], want contain [%v]", err, "type has a [kind] property")
+	}
+}
+
+func TestPolymorphicCodec_errors(t *testing.T) {
+	codec := testPolymorphicCodec()
+	var drawing testDrawing
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "missing discriminator", err: codec.Unmarshal([]byte(`{"main":{"radius":1}}`), &drawing),
+			want: "[kind] discriminator is required to decode [bvmgo_reflect.testShape] interface"},
+		{name: "unknown type", err: codec.Unmarshal([]byte(`{"main":{"kind":"triangle"}}`), &drawing),
+			want: "[triangle] type name is not registered"},
+		{name: "not a pointer", err: codec.Unmarshal([]byte(`{}`), drawing),
+			want: "a pointer is required to unmarshal JSON"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Errorf("returns nil (no error), want an error")
+				return
+			}
+			if !strings.Contains(tt.err.Error(), tt.want) {
+				t.Errorf("err.Error() = [%v], want contain [%v]", tt.err.Error(), tt.want)
+			}
+		})
+	}
+	_, err := NewPolymorphicCodec(NewTypeRegistry(), "").Marshal(testDrawing{Main: testCircle{}})
+	if err == nil || !strings.Contains(err.Error(), "type is not registered") {
+		t.Errorf("Marshal(...) error = [%v], want contain [%v]", err, "type is not registered")
+	}
+}
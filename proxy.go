@@ -0,0 +1,182 @@
+package bvmgo_reflect
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Call describes an intercepted function call.
+type Call struct {
+	// Name is the name of the called function.
+	Name string
+	// Arguments are the call arguments (variadic arguments are the last argument, as a slice).
+	// Interceptors may replace them before calling Proceed.
+	Arguments []any
+	// Results are the call results, set by Proceed. Interceptors may replace them.
+	Results []any
+
+	function     reflect.Value
+	interceptors []Interceptor
+	next         int
+}
+
+// Interceptor is called instead of the wrapped function.
+//
+// It calls Proceed to call the next interceptor, then the wrapped function (zero, one or several times)
+// and may read or replace call Arguments and Results.
+type Interceptor func(call *Call)
+
+// Proceed function calls the next interceptor (or the wrapped function) with call Arguments,
+// stores and returns call Results.
+//
+// Proceed function panics if Arguments are incompatible with the wrapped function parameters.
+func (call *Call) Proceed() []any {
+	if call.next < len(call.interceptors) {
+		interceptor := call.interceptors[call.next]
+		call.next++
+		defer func() { call.next-- }()
+		interceptor(call)
+		return call.Results
+	}
+	functionType := call.function.Type()
+	if len(call.Arguments) != functionType.NumIn() {
+		panic(fmt.Sprintf("[%s] function requires %d arguments, got %d",
+			call.Name, functionType.NumIn(), len(call.Arguments)))
+	}
+	argumentValues := make([]reflect.Value, len(call.Arguments))
+	for index, argument := range call.Arguments {
+		argumentValues[index] = reflect.New(functionType.In(index)).Elem()
+		if err := setValueToReflectValue(argumentValues[index], argument); err != nil {
+			panic(fmt.Sprintf("[%s] function argument [%d] cannot be set with current value: %v", call.Name, index, err))
+		}
+	}
+	var resultValues []reflect.Value
+	if functionType.IsVariadic() {
+		resultValues = call.function.CallSlice(argumentValues)
+	} else {
+		resultValues = call.function.Call(argumentValues)
+	}
+	call.Results = make([]any, len(resultValues))
+	for index, resultValue := range resultValues {
+		call.Results[index] = resultValue.Interface()
+	}
+	return call.Results
+}
+
+// Err function returns the last result if it is a not nil error.
+func (call *Call) Err() error {
+	if len(call.Results) == 0 {
+		return nil
+	}
+	err, _ := call.Results[len(call.Results)-1].(error)
+	return err
+}
+
+// Wrap function returns a function of the same type as function which calls interceptors
+// (in order) around function. The call name is the function name.
+//
+// Wrap function returns an error if function is not a function or is nil.
+func Wrap[F any](function F, interceptors ...Interceptor) (F, error) {
+	functionValue := reflect.ValueOf(function)
+	name := ""
+	if functionValue.Kind() == reflect.Func && !functionValue.IsNil() {
+		name = functionName(functionValue)
+	}
+	return WrapNamed(name, function, interceptors...)
+}
+
+// WrapNamed function returns a function of the same type as function which calls interceptors
+// (in order) around function, with a call name.
+//
+// WrapNamed function returns an error if function is not a function or is nil.
+func WrapNamed[F any](name string, function F, interceptors ...Interceptor) (F, error) {
+	functionValue := reflect.ValueOf(function)
+	if !functionValue.IsValid() || functionValue.Kind() != reflect.Func {
+		var zero F
+		return zero, fmt.Errorf("unsupported type [%s], a function is required to wrap [%s]",
+			TypeName(function), name)
+	}
+	if functionValue.IsNil() {
+		var zero F
+		return zero, fmt.Errorf("a not nil function is required to wrap [%s]", name)
+	}
+	wrapped := wrapFunction(name, functionValue, interceptors)
+	return wrapped.Interface().(F), nil
+}
+
+// WrapStruct function replaces each not nil exported function field of a structure by a function
+// calling interceptors around the original function. Call names are "Type.Field".
+//
+// WrapStruct function returns an error if:
+//   - targetStructurePointer is not a pointer to a structure,
+//   - a function field cannot be set.
+func WrapStruct(targetStructurePointer any, interceptors ...Interceptor) error {
+	ptrTarget := reflect.ValueOf(targetStructurePointer)
+	// Check is not null
+	if !ptrTarget.IsValid() || (ptrTarget.Kind() == reflect.Ptr && ptrTarget.IsNil()) {
+		return fmt.Errorf("a not nil pointer is required to wrap structure functions")
+	}
+	// Check is a pointer to a structure
+	if ptrTarget.Kind() != reflect.Ptr || ptrTarget.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported type [%s], a pointer to a structure is required to wrap structure functions",
+			typeName(ptrTarget.Type()))
+	}
+	structValue := ptrTarget.Elem()
+	structType := structValue.Type()
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		fieldValue := structValue.Field(index)
+		if !field.IsExported() || fieldValue.Kind() != reflect.Func || fieldValue.IsNil() {
+			continue
+		}
+		name := structType.Name() + "." + field.Name
+		// Copy the original function: the field value is replaced by the wrapped function
+		original := reflect.ValueOf(fieldValue.Interface())
+		wrapped := wrapFunction(name, original, interceptors)
+		if err := SetField(targetStructurePointer, field.Name, wrapped.Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wrapFunction function returns a function of the same type as functionValue which calls interceptors around it.
+func wrapFunction(name string, functionValue reflect.Value, interceptors []Interceptor) reflect.Value {
+	functionType := functionValue.Type()
+	return reflect.MakeFunc(functionType, func(argumentValues []reflect.Value) []reflect.Value {
+		call := &Call{
+			Name:         name,
+			Arguments:    make([]any, len(argumentValues)),
+			function:     functionValue,
+			interceptors: interceptors,
+		}
+		for index, argumentValue := range argumentValues {
+			call.Arguments[index] = argumentValue.Interface()
+		}
+		call.Proceed()
+		if len(call.Results) != functionType.NumOut() {
+			panic(fmt.Sprintf("[%s] function returns %d results, got %d",
+				name, functionType.NumOut(), len(call.Results)))
+		}
+		resultValues := make([]reflect.Value, len(call.Results))
+		for index, result := range call.Results {
+			resultValues[index] = reflect.New(functionType.Out(index)).Elem()
+			if err := setValueToReflectValue(resultValues[index], result); err != nil {
+				panic(fmt.Sprintf("[%s] function result [%d] cannot be set with current value: %v", name, index, err))
+			}
+		}
+		return resultValues
+	})
+}
+
+// functionName function returns the short name of a function ("package.Function").
+func functionName(functionValue reflect.Value) string {
+	runtimeFunction := runtime.FuncForPC(functionValue.Pointer())
+	if runtimeFunction == nil {
+		return typeName(functionValue.Type())
+	}
+	name := runtimeFunction.Name()
+	return name[strings.LastIndex(name, "/")+1:]
+}
@@ -0,0 +1,121 @@
+package bvmgo_reflect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testProxyClient struct {
+	Get     func(key string) (string, error)
+	Sum     func(values ...int) int
+	Missing func()
+	private func()
+}
+
+func testProxyAdd(a int, b int) int {
+	return a + b
+}
+
+func TestWrap_logging(t *testing.T) {
+	var logs []string
+	logger := func(call *Call) {
+		logs = append(logs, fmt.Sprintf("before %s%v", call.Name, call.Arguments))
+		call.Proceed()
+		logs = append(logs, fmt.Sprintf("after %s%v", call.Name, call.Results))
+	}
+	add, err := Wrap(testProxyAdd, logger)
+	if err != nil {
+		t.Errorf("Wrap(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if got := add(2, 3); got != 5 {
+		t.Errorf("add(2, 3) = %v, want %v", got, 5)
+	}
+	expected := []string{"before bvmgo-reflect.testProxyAdd[2 3]", "after bvmgo-reflect.testProxyAdd[5]"}
+	if !reflect.DeepEqual(logs, expected) {
+		t.Errorf("logs = %v, want %v", logs, expected)
+	}
+}
+
+func TestWrap_chainAndOverride(t *testing.T) {
+	var order []string
+	first := func(call *Call) {
+		order = append(order, "first")
+		call.Arguments[0] = 10
+		call.Proceed()
+	}
+	second := func(call *Call) {
+		order = append(order, "second")
+		call.Proceed()
+		call.Results[0] = call.Results[0].(int) * 2
+	}
+	add, _ := WrapNamed("add", testProxyAdd, first, second)
+	if got := add(1, 2); got != 24 {
+		t.Errorf("add(1, 2) = %v, want %v", got, 24)
+	}
+	if !reflect.DeepEqual(order, []string{"first", "second"}) {
+		t.Errorf("order = %v, want %v", order, []string{"first", "second"})
+	}
+}
+
+func TestWrapStruct_retry(t *testing.T) {
+	attempts := 0
+	client := testProxyClient{
+		Get: func(key string) (string, error) {
+			attempts++
+			if attempts < 3 {
+				return "", errors.New("unavailable")
+			}
+			return "value of " + key, nil
+		},
+		Sum: func(values ...int) int {
+			total := 0
+			for _, value := range values {
+				total += value
+			}
+			return total
+		},
+	}
+	var names []string
+	retry := func(call *Call) {
+		names = append(names, call.Name)
+		for attempt := 0; attempt < 5; attempt++ {
+			if call.Proceed(); call.Err() == nil {
+				return
+			}
+		}
+	}
+	if err := WrapStruct(&client, retry); err != nil {
+		t.Errorf("WrapStruct(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	value, err := client.Get("key")
+	if err != nil || value != "value of key" || attempts != 3 {
+		t.Errorf("client.Get() = [%v, %v] after %d attempts, want [value of key, nil] after 3 attempts",
+			value, err, attempts)
+	}
+	if got := client.Sum(1, 2, 3); got != 6 {
+		t.Errorf("client.Sum(1, 2, 3) = %v, want %v", got, 6)
+	}
+	if client.Missing != nil {
+		t.Errorf("client.Missing = a function, want nil")
+	}
+	expectedNames := []string{"testProxyClient.Get", "testProxyClient.Sum"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("names = %v, want %v", names, expectedNames)
+	}
+}
+
+func TestWrap_notFunction(t *testing.T) {
+	_, err := Wrap("not a function")
+	if err == nil {
+		t.Errorf("Wrap(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "a function is required") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "a function is required")
+	}
+}
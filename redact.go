@@ -0,0 +1,230 @@
+package bvmgo_reflect
+
+import (
+	"path"
+	"reflect"
+	"strings"
+)
+
+// DefaultRedactMask is the default replacement of redacted strings.
+const DefaultRedactMask = "******"
+
+// DefaultRedactPatterns are the default patterns of redacted field names and map keys.
+var DefaultRedactPatterns = []string{"*password", "*token", "*secret", "*key"}
+
+// RedactOptions configures RedactWith function.
+type RedactOptions struct {
+	// Mask replaces redacted strings (DefaultRedactMask if empty).
+	Mask string
+	// Patterns are the case-insensitive patterns (see path.Match) of redacted field names and map keys
+	// (DefaultRedactPatterns if nil).
+	Patterns []string
+}
+
+// Redact function returns a deep copy of v where secrets are masked, v is left untouched.
+//
+// Secrets are the structure fields tagged `secret:""` and the structure fields and string map keys
+// matching DefaultRedactPatterns (like "Password", "Token" or "APIKey").
+// Secret strings (and pointers to strings) are replaced by DefaultRedactMask, other secret values
+// are replaced by their zero value.
+// Nested structures (including embedded and unexported fields), maps, slices, arrays, pointers
+// and interfaces are redacted; shared pointers, maps and slices (and cycles) are copied once.
+// Unexported fields of structures from other packages than the first redacted structure
+// (sync.Mutex state, *time.Location...) are neither redacted nor copied, they are shared with v.
+func Redact(v any) any {
+	return RedactWith(v, RedactOptions{})
+}
+
+// RedactWith function returns a deep copy of v where secrets are masked according to options.
+//
+// See Redact function.
+func RedactWith(v any, options RedactOptions) any {
+	if len(options.Mask) == 0 {
+		options.Mask = DefaultRedactMask
+	}
+	if options.Patterns == nil {
+		options.Patterns = DefaultRedactPatterns
+	}
+	value := reflect.ValueOf(v)
+	if !value.IsValid() {
+		return nil
+	}
+	redactor := valueRedactor{options: options, copies: make(map[visitedPointer]reflect.Value)}
+	return redactor.redact(value).Interface()
+}
+
+// valueRedactor copies values and keeps pointer copies (shared pointers and cycles).
+type valueRedactor struct {
+	options RedactOptions
+	copies  map[visitedPointer]reflect.Value
+	// copyOnly is true to deep copy values without masking secrets
+	copyOnly bool
+	// localPackage is the package of the first redacted structure, whose unexported fields are redacted
+	localPackage string
+}
+
+// deepCopy function returns a deep copy of value.
+func deepCopy(value reflect.Value) reflect.Value {
+	if !value.IsValid() {
+		return value
+	}
+	redactor := valueRedactor{copies: make(map[visitedPointer]reflect.Value), copyOnly: true}
+	return redactor.redact(value)
+}
+
+// isSecretName function returns true if name matches a redact pattern.
+func (redactor *valueRedactor) isSecretName(name string) bool {
+	if redactor.copyOnly {
+		return false
+	}
+	name = strings.ToLower(name)
+	for _, pattern := range redactor.options.Patterns {
+		if matched, _ := path.Match(strings.ToLower(pattern), name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// isSecretField function returns true if field is tagged `secret:""` or matches a redact pattern.
+//
+// Embedded structures are never secrets, their fields are redacted.
+func (redactor *valueRedactor) isSecretField(field reflect.StructField) bool {
+	if redactor.copyOnly {
+		return false
+	}
+	if _, tagged := field.Tag.Lookup("secret"); tagged {
+		return true
+	}
+	return !(field.Anonymous && field.Type.Kind() == reflect.Struct) && redactor.isSecretName(field.Name)
+}
+
+// isLocalStruct function returns true if the unexported fields of a structure type are redacted:
+// unnamed structures and structures of the package of the first named structure.
+func (redactor *valueRedactor) isLocalStruct(structType reflect.Type) bool {
+	if len(structType.PkgPath()) == 0 {
+		return true
+	}
+	if len(redactor.localPackage) == 0 {
+		redactor.localPackage = structType.PkgPath()
+	}
+	return structType.PkgPath() == redactor.localPackage
+}
+
+// redact function returns a redacted copy of value.
+func (redactor *valueRedactor) redact(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return value
+		}
+		key := visitedPointer{pointer: value.Pointer(), pointerType: value.Type()}
+		if copied, ok := redactor.copies[key]; ok {
+			return copied
+		}
+		copied := reflect.New(value.Type().Elem())
+		redactor.copies[key] = copied
+		copied.Elem().Set(redactor.redact(value.Elem()))
+		return copied
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(redactor.redact(value.Elem()))
+		return copied
+	case reflect.Struct:
+		structType := value.Type()
+		copied := reflect.New(structType).Elem()
+		// Shallow copy, then each field is replaced by its redacted copy
+		copied.Set(value)
+		local := redactor.isLocalStruct(structType)
+		for index := 0; index < structType.NumField(); index++ {
+			field := structType.Field(index)
+			source, target := value.Field(index), copied.Field(index)
+			if !field.IsExported() {
+				if !local {
+					// Internals of other packages are kept as is
+					continue
+				}
+				// Unexported fields are read and written through their address in the shallow copy
+				target = reflect.NewAt(field.Type, target.Addr().UnsafePointer()).Elem()
+				source = target
+			}
+			if redactor.isSecretField(field) {
+				target.Set(redactor.mask(source))
+			} else {
+				target.Set(redactor.redact(source))
+			}
+		}
+		return copied
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		key := visitedPointer{pointer: value.Pointer(), pointerType: value.Type()}
+		if copied, ok := redactor.copies[key]; ok {
+			return copied
+		}
+		copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+		redactor.copies[key] = copied
+		iterator := value.MapRange()
+		for iterator.Next() {
+			key := iterator.Key()
+			if key.Kind() == reflect.String && redactor.isSecretName(key.String()) {
+				copied.SetMapIndex(key, redactor.mask(iterator.Value()))
+			} else {
+				copied.SetMapIndex(key, redactor.redact(iterator.Value()))
+			}
+		}
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		key := visitedPointer{pointer: value.Pointer(), pointerType: value.Type()}
+		// Slices sharing their array are copied once if they have the same length
+		if copied, ok := redactor.copies[key]; ok && copied.Len() == value.Len() {
+			return copied
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		if value.Len() > 0 {
+			redactor.copies[key] = copied
+		}
+		for index := 0; index < value.Len(); index++ {
+			copied.Index(index).Set(redactor.redact(value.Index(index)))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(value.Type()).Elem()
+		for index := 0; index < value.Len(); index++ {
+			copied.Index(index).Set(redactor.redact(value.Index(index)))
+		}
+		return copied
+	default:
+		return value
+	}
+}
+
+// mask function returns the masked value of a secret (mask for strings, zero value otherwise).
+func (redactor *valueRedactor) mask(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(redactor.options.Mask).Convert(value.Type())
+	case reflect.Ptr:
+		if value.IsNil() || value.Type().Elem().Kind() != reflect.String {
+			break
+		}
+		masked := reflect.New(value.Type().Elem())
+		masked.Elem().Set(redactor.mask(value.Elem()))
+		return masked
+	case reflect.Interface:
+		if value.IsNil() || value.Elem().Kind() != reflect.String {
+			break
+		}
+		masked := reflect.New(value.Type()).Elem()
+		masked.Set(redactor.mask(value.Elem()))
+		return masked
+	}
+	return reflect.Zero(value.Type())
+}
@@ -0,0 +1,128 @@
+package bvmgo_reflect
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testRedactCredentials struct {
+	User     string
+	Password string
+	APIKey   *string
+	PIN      int `secret:""`
+}
+
+type testRedactDatabase struct {
+	Host     string
+	Password string
+}
+
+type testRedactConfig struct {
+	testRedactDatabase
+	Name        string
+	Credentials testRedactCredentials
+	Backups     []*testRedactCredentials
+	Headers     map[string]any
+	Next        *testRedactConfig
+	token       string
+	password    *string
+	settings    map[string]string
+}
+
+func TestRedact(t *testing.T) {
+	key := "key-value"
+	password := "unexported-password"
+	original := &testRedactConfig{
+		testRedactDatabase: testRedactDatabase{Host: "db", Password: "db-pass"},
+		Name:               "app",
+		Credentials:        testRedactCredentials{User: "alice", Password: "pass", APIKey: &key, PIN: 1234},
+		Backups:            []*testRedactCredentials{{User: "bob", Password: "bob-pass"}},
+		Headers:            map[string]any{"Authorization": "Bearer x", "X-Auth-Token": "abc", "Accept": "json"},
+		token:              "unexported",
+		password:           &password,
+		settings:           map[string]string{"mode": "fast"},
+	}
+	original.Next = original
+	redacted, ok := Redact(original).(*testRedactConfig)
+	if !ok {
+		t.Errorf("Redact(...) returns %T, want *testRedactConfig", Redact(original))
+		return
+	}
+	if redacted == original || redacted.Next != redacted {
+		t.Errorf("Redact(...) returns no deep copy (or breaks cycle)")
+	}
+	if got := fmt.Sprintf("%+v %v", redacted.Credentials, *redacted.Credentials.APIKey); strings.Contains(got, "pass") ||
+		strings.Contains(got, "key-value") || strings.Contains(got, "1234") {
+		t.Errorf("Redact(...) credentials = %s, want redacted secrets", got)
+	}
+	if redacted.Credentials.User != "alice" || redacted.Credentials.Password != DefaultRedactMask {
+		t.Errorf("Redact(...) credentials = %+v, want alice user and masked password", redacted.Credentials)
+	}
+	if redacted.Backups[0].Password != DefaultRedactMask || redacted.Backups[0].User != "bob" {
+		t.Errorf("Redact(...) backups = %+v, want masked password", *redacted.Backups[0])
+	}
+	if redacted.Headers["X-Auth-Token"] != DefaultRedactMask || redacted.Headers["Accept"] != "json" {
+		t.Errorf("Redact(...) headers = %v, want masked token", redacted.Headers)
+	}
+	if redacted.token != DefaultRedactMask || redacted.password == &password || *redacted.password != DefaultRedactMask {
+		t.Errorf("Redact(...) unexported secrets = %q and %q, want masked copies", redacted.token, *redacted.password)
+	}
+	if redacted.testRedactDatabase.Password != DefaultRedactMask || redacted.Host != "db" {
+		t.Errorf("Redact(...) embedded structure = %+v, want masked password", redacted.testRedactDatabase)
+	}
+	redacted.settings["mode"] = "slow"
+	if got := fmt.Sprintf("%+v", *redacted); strings.Contains(got, "db-pass") || strings.Contains(got, "unexported") {
+		t.Errorf("Redact(...) = %s, want no secret", got)
+	}
+	// Original is left untouched
+	if original.Credentials.Password != "pass" || key != "key-value" || original.Credentials.PIN != 1234 ||
+		original.token != "unexported" || password != "unexported-password" || original.settings["mode"] != "fast" ||
+		original.testRedactDatabase.Password != "db-pass" ||
+		original.Backups[0].Password != "bob-pass" || original.Headers["X-Auth-Token"] != "abc" {
+		t.Errorf("Redact(...) modifies original value: %+v", original)
+	}
+}
+
+func TestRedactWith(t *testing.T) {
+	values := map[string]string{"Authorization": "Bearer x", "password": "y"}
+	redacted := RedactWith(values, RedactOptions{Mask: "[hidden]", Patterns: []string{"authorization"}}).(map[string]string)
+	if redacted["Authorization"] != "[hidden]" || redacted["password"] != "y" {
+		t.Errorf("RedactWith(...) = %v, want only authorization hidden", redacted)
+	}
+	if Redact(nil) != nil {
+		t.Errorf("Redact(nil) = %v, want nil", Redact(nil))
+	}
+}
+
+func TestRedact_cycles(t *testing.T) {
+	values := map[string]any{"token": "abc"}
+	values["self"] = values
+	redacted := Redact(values).(map[string]any)
+	if redacted["token"] != DefaultRedactMask || redacted["self"].(map[string]any)["token"] != DefaultRedactMask {
+		t.Errorf("Redact(map cycle) = %v, want masked token", redacted)
+	}
+	list := []any{"a", nil}
+	list[1] = list
+	redactedList := Redact(list).([]any)
+	if redactedList[1].([]any)[0] != "a" {
+		t.Errorf("Redact(slice cycle) = %v, want copied slice", redactedList)
+	}
+}
+
+type testRedactSession struct {
+	Created time.Time
+	token   string
+}
+
+func TestRedact_foreignInternals(t *testing.T) {
+	original := testRedactSession{Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("X", 3600)), token: "abc"}
+	redacted := Redact(original).(testRedactSession)
+	if redacted.token != DefaultRedactMask {
+		t.Errorf("redacted.token = %v, want %v", redacted.token, DefaultRedactMask)
+	}
+	if redacted.Created.Location() != original.Created.Location() || !redacted.Created.Equal(original.Created) {
+		t.Errorf("redacted.Created = %v, want %v with the same location", redacted.Created, original.Created)
+	}
+}
@@ -0,0 +1,178 @@
+package bvmgo_reflect
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// TypeRegistry maps stable names (and aliases) to types. A TypeRegistry is safe for concurrent use.
+type TypeRegistry struct {
+	mutex   sync.RWMutex
+	types   map[string]reflect.Type
+	aliases map[string]string
+	names   map[reflect.Type]string
+}
+
+// DefaultTypeRegistry is the registry used by Register, Lookup and New functions.
+var DefaultTypeRegistry = NewTypeRegistry()
+
+// NewTypeRegistry function returns a new empty registry.
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{
+		types:   make(map[string]reflect.Type),
+		aliases: make(map[string]string),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// Register function registers T type in the default registry, named from TypeNameOf, with optional aliases.
+//
+// See TypeRegistry.RegisterType function.
+func Register[T any](aliases ...string) error {
+	return DefaultTypeRegistry.RegisterType(reflect.TypeOf((*T)(nil)).Elem(), "", aliases...)
+}
+
+// RegisterNamed function registers T type in the default registry with a name and optional aliases.
+//
+// See TypeRegistry.RegisterType function.
+func RegisterNamed[T any](name string, aliases ...string) error {
+	return DefaultTypeRegistry.RegisterType(reflect.TypeOf((*T)(nil)).Elem(), name, aliases...)
+}
+
+// Lookup function returns the type registered with name (or alias) in the default registry.
+func Lookup(name string) (reflect.Type, bool) {
+	return DefaultTypeRegistry.Lookup(name)
+}
+
+// New function returns a pointer to a new zero value of the type registered with name in the default registry.
+//
+// See TypeRegistry.New function.
+func New(name string) (any, error) {
+	return DefaultTypeRegistry.New(name)
+}
+
+// RegisterType function registers a type with a name (type name from TypeName if empty) and optional aliases.
+//
+// Registering the same type with the same name again is allowed, aliases are added.
+//
+// RegisterType function returns an error if:
+//   - registeredType is nil,
+//   - name (or an alias) is already registered for another type,
+//   - registeredType is already registered with another name.
+func (registry *TypeRegistry) RegisterType(registeredType reflect.Type, name string, aliases ...string) error {
+	if registeredType == nil {
+		return fmt.Errorf("a not nil type is required to register a type")
+	}
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		name = typeName(registeredType)
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if existingName, ok := registry.names[registeredType]; ok && existingName != name {
+		return fmt.Errorf("[%s] type is already registered with [%s] name", typeName(registeredType), existingName)
+	}
+	if err := registry.checkNameAvailable(name, registeredType); err != nil {
+		return err
+	}
+	for _, alias := range aliases {
+		if err := registry.checkNameAvailable(strings.TrimSpace(alias), registeredType); err != nil {
+			return err
+		}
+	}
+	registry.types[name] = registeredType
+	registry.names[registeredType] = name
+	for _, alias := range aliases {
+		registry.aliases[strings.TrimSpace(alias)] = name
+	}
+	return nil
+}
+
+// RegisterAlias function adds an alias to a registered name, for instance the previous name of a renamed type.
+//
+// RegisterAlias function returns an error if:
+//   - name is not registered,
+//   - alias is already registered for another type.
+func (registry *TypeRegistry) RegisterAlias(alias string, name string) error {
+	alias = strings.TrimSpace(alias)
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registeredType, ok := registry.types[strings.TrimSpace(name)]
+	if !ok {
+		return fmt.Errorf("[%s] type name is not registered", name)
+	}
+	if err := registry.checkNameAvailable(alias, registeredType); err != nil {
+		return err
+	}
+	registry.aliases[alias] = strings.TrimSpace(name)
+	return nil
+}
+
+// checkNameAvailable function returns an error if name is empty or already registered for another type.
+//
+// Registry mutex must be locked.
+func (registry *TypeRegistry) checkNameAvailable(name string, registeredType reflect.Type) error {
+	if len(name) == 0 {
+		return fmt.Errorf("type name is empty")
+	}
+	existingType, ok := registry.types[name]
+	if !ok {
+		if canonicalName, isAlias := registry.aliases[name]; isAlias {
+			existingType, ok = registry.types[canonicalName]
+		}
+	}
+	if ok && existingType != registeredType {
+		return fmt.Errorf("[%s] type name is already registered for [%s] type", name, typeName(existingType))
+	}
+	return nil
+}
+
+// Lookup function returns the type registered with name (or alias).
+func (registry *TypeRegistry) Lookup(name string) (reflect.Type, bool) {
+	name = strings.TrimSpace(name)
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	if registeredType, ok := registry.types[name]; ok {
+		return registeredType, true
+	}
+	if canonicalName, ok := registry.aliases[name]; ok {
+		return registry.types[canonicalName], true
+	}
+	return nil, false
+}
+
+// NameOf function returns the name of a registered type (not an alias).
+func (registry *TypeRegistry) NameOf(registeredType reflect.Type) (string, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	name, ok := registry.names[registeredType]
+	return name, ok
+}
+
+// Names function returns the sorted names of registered types (aliases excluded).
+func (registry *TypeRegistry) Names() []string {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	names := make([]string, 0, len(registry.types))
+	for name := range registry.types {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// New function returns a pointer to a new zero value of the type registered with name (or alias).
+//
+// For a T type registered, New function returns a *T as any.
+//
+// New function returns an error if name is not registered.
+func (registry *TypeRegistry) New(name string) (any, error) {
+	registeredType, ok := registry.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("[%s] type name is not registered", strings.TrimSpace(name))
+	}
+	return reflect.New(registeredType).Interface(), nil
+}
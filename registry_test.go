@@ -0,0 +1,136 @@
+package bvmgo_reflect
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testRegistryCircle struct {
+	Radius float64
+}
+
+type testRegistrySquare struct {
+	Side float64
+}
+
+func TestTypeRegistry_registerAndNew(t *testing.T) {
+	registry := NewTypeRegistry()
+	if err := registry.RegisterType(reflect.TypeOf(testRegistryCircle{}), "", "circle"); err != nil {
+		t.Errorf("RegisterType(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	tests := []string{"bvmgo_reflect.testRegistryCircle", "circle"}
+	for _, name := range tests {
+		value, err := registry.New(name)
+		if err != nil {
+			t.Errorf("New(%s) returns \"%v\" error, want no error", name, err)
+			continue
+		}
+		if _, ok := value.(*testRegistryCircle); !ok {
+			t.Errorf("New(%s) = %v, want a *testRegistryCircle", name, TypeName(value))
+		}
+	}
+	if name, _ := registry.NameOf(reflect.TypeOf(testRegistryCircle{})); name != "bvmgo_reflect.testRegistryCircle" {
+		t.Errorf("NameOf(...) = %v, want %v", name, "bvmgo_reflect.testRegistryCircle")
+	}
+}
+
+func TestTypeRegistry_collision(t *testing.T) {
+	registry := NewTypeRegistry()
+	if err := registry.RegisterType(reflect.TypeOf(testRegistryCircle{}), "shape"); err != nil {
+		t.Errorf("RegisterType(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	// Same type and name: no error
+	if err := registry.RegisterType(reflect.TypeOf(testRegistryCircle{}), "shape"); err != nil {
+		t.Errorf("RegisterType(...) returns \"%v\" error, want no error", err)
+	}
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "name collision", err: registry.RegisterType(reflect.TypeOf(testRegistrySquare{}), "shape"),
+			want: "[shape] type name is already registered for [bvmgo_reflect.testRegistryCircle] type"},
+		{name: "other name", err: registry.RegisterType(reflect.TypeOf(testRegistryCircle{}), "circle"),
+			want: "type is already registered with [shape] name"},
+		{name: "alias collision", err: registry.RegisterType(reflect.TypeOf(testRegistrySquare{}), "square", "shape"),
+			want: "[shape] type name is already registered"},
+		{name: "unknown alias target", err: registry.RegisterAlias("old", "unknown"),
+			want: "[unknown] type name is not registered"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Errorf("returns nil (no error), want an error")
+				return
+			}
+			if !strings.Contains(tt.err.Error(), tt.want) {
+				t.Errorf("err.Error() = [%v], want contain [%v]", tt.err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeRegistry_alias(t *testing.T) {
+	registry := NewTypeRegistry()
+	_ = registry.RegisterType(reflect.TypeOf(testRegistrySquare{}), "square")
+	if err := registry.RegisterAlias("legacy.Square", "square"); err != nil {
+		t.Errorf("RegisterAlias(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if registeredType, ok := registry.Lookup("legacy.Square"); !ok || registeredType != reflect.TypeOf(testRegistrySquare{}) {
+		t.Errorf("Lookup(...) = %v, want %v", registeredType, "bvmgo_reflect.testRegistrySquare")
+	}
+	if names := registry.Names(); !reflect.DeepEqual(names, []string{"square"}) {
+		t.Errorf("Names() = %v, want %v", names, []string{"square"})
+	}
+}
+
+func TestTypeRegistry_notRegistered(t *testing.T) {
+	_, err := NewTypeRegistry().New("unknown")
+	if err == nil {
+		t.Errorf("New(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "type name is not registered") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "type name is not registered")
+	}
+}
+
+func TestTypeRegistry_concurrent(t *testing.T) {
+	registry := NewTypeRegistry()
+	var waitGroup sync.WaitGroup
+	for index := 0; index < 20; index++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			_ = registry.RegisterType(reflect.TypeOf(testRegistryCircle{}), "circle")
+			_, _ = registry.New("circle")
+		}()
+	}
+	waitGroup.Wait()
+	if _, ok := registry.Lookup("circle"); !ok {
+		t.Errorf("Lookup(...) returns false, want true")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if err := Register[testRegistrySquare]("test.Square"); err != nil {
+		t.Errorf("Register(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	value, err := New("test.Square")
+	if err != nil {
+		t.Errorf("New(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if _, ok := value.(*testRegistrySquare); !ok {
+		t.Errorf("New(...) = %v, want a *testRegistrySquare", TypeName(value))
+	}
+	if _, ok := Lookup("bvmgo_reflect.testRegistrySquare"); !ok {
+		t.Errorf("Lookup(...) returns false, want true")
+	}
+}
@@ -0,0 +1,304 @@
+package bvmgo_reflect
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect produced by Schema function.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is a JSON Schema document (or sub-schema), encodable with encoding/json.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	ContentEncoding      string                 `json:"contentEncoding,omitempty"`
+	Enum                 []any                  `json:"enum,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	MinItems             *int                   `json:"minItems,omitempty"`
+	MaxItems             *int                   `json:"maxItems,omitempty"`
+}
+
+// Schema function returns the JSON Schema (draft 2020-12) of a Go type.
+//
+// Structures are objects whose properties are exported fields, named by "json" tag (encoding/json rules).
+// A property is required if its field is not a pointer and not tagged "omitempty",
+// or if its field is tagged `validate:"required"`. `validate:"oneof=a b c"` tag defines an enum.
+// time.Time values are "date-time" strings, UUID types ([16]byte named "...UUID") are "uuid" strings
+// and encoding.TextMarshaler types are strings.
+// Named structures used more than once (or recursively) are defined once in "$defs",
+// named from TypeName, and referenced with "$ref".
+//
+// Schema function returns an error if:
+//   - schemaType is nil,
+//   - schemaType (or one of its fields) cannot be encoded in JSON (channel, function, complex...).
+func Schema(schemaType reflect.Type) (*JSONSchema, error) {
+	if schemaType == nil {
+		return nil, fmt.Errorf("a not nil type is required to build a JSON schema")
+	}
+	for schemaType.Kind() == reflect.Ptr {
+		schemaType = schemaType.Elem()
+	}
+	generator := schemaGenerator{
+		root:     schemaType,
+		counts:   make(map[reflect.Type]int),
+		names:    make(map[reflect.Type]string),
+		usedName: make(map[string]bool),
+		defs:     make(map[string]*JSONSchema),
+	}
+	generator.countTypes(schemaType)
+	schema, err := generator.inlineSchema(schemaType)
+	if err != nil {
+		return nil, err
+	}
+	schema.Schema = JSONSchemaDraft
+	if len(generator.defs) > 0 {
+		schema.Defs = generator.defs
+	}
+	return schema, nil
+}
+
+// schemaGenerator builds JSON schemas and their definitions.
+type schemaGenerator struct {
+	root     reflect.Type
+	counts   map[reflect.Type]int
+	names    map[reflect.Type]string
+	usedName map[string]bool
+	defs     map[string]*JSONSchema
+}
+
+// jsonField is a structure field encoded as a JSON property.
+type jsonField struct {
+	name      string
+	field     reflect.StructField
+	index     []int
+	omitEmpty bool
+	asString  bool
+}
+
+// isDefinableType function returns true if type is a named structure (candidate to "$defs").
+func isDefinableType(schemaType reflect.Type) bool {
+	return schemaType.Kind() == reflect.Struct && len(schemaType.Name()) > 0 && !isStringSchemaType(schemaType)
+}
+
+// isStringSchemaType function returns true if type is encoded as a JSON string by its MarshalText method.
+func isStringSchemaType(schemaType reflect.Type) bool {
+	return schemaType.Implements(textMarshalerType) || reflect.PointerTo(schemaType).Implements(textMarshalerType)
+}
+
+// countTypes function counts named structures usages (a recursive structure is counted twice).
+func (generator *schemaGenerator) countTypes(schemaType reflect.Type) {
+	switch schemaType.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		generator.countTypes(schemaType.Elem())
+	case reflect.Map:
+		generator.countTypes(schemaType.Elem())
+	case reflect.Struct:
+		if isDefinableType(schemaType) {
+			generator.counts[schemaType]++
+			if generator.counts[schemaType] > 1 {
+				return
+			}
+		}
+		if isStringSchemaType(schemaType) {
+			return
+		}
+		for _, field := range jsonFields(schemaType) {
+			generator.countTypes(field.field.Type)
+		}
+	}
+}
+
+// schemaOf function returns the schema of a type, or a reference to its definition.
+func (generator *schemaGenerator) schemaOf(schemaType reflect.Type) (*JSONSchema, error) {
+	if !isDefinableType(schemaType) || generator.counts[schemaType] < 2 {
+		return generator.inlineSchema(schemaType)
+	}
+	if schemaType == generator.root {
+		return &JSONSchema{Ref: "#"}, nil
+	}
+	name, defined := generator.names[schemaType]
+	if !defined {
+		name = generator.definitionName(schemaType)
+		generator.names[schemaType] = name
+		// Register definition before building it (recursive types)
+		generator.defs[name] = &JSONSchema{}
+		schema, err := generator.inlineSchema(schemaType)
+		if err != nil {
+			return nil, err
+		}
+		generator.defs[name] = schema
+	}
+	return &JSONSchema{Ref: definitionRef(name)}, nil
+}
+
+// definitionRef function returns the reference to a "$defs" definition: a JSON pointer ("~" and "/" escaped)
+// in a URI fragment (percent-encoded).
+func definitionRef(name string) string {
+	pointer := strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+	return "#/$defs/" + url.PathEscape(pointer)
+}
+
+// definitionName function returns a unique "$defs" name for type.
+func (generator *schemaGenerator) definitionName(schemaType reflect.Type) string {
+	baseName := typeName(schemaType)
+	name := baseName
+	for suffix := 2; generator.usedName[name]; suffix++ {
+		name = baseName + "_" + strconv.Itoa(suffix)
+	}
+	generator.usedName[name] = true
+	return name
+}
+
+// inlineSchema function returns the schema of a type (not a reference).
+func (generator *schemaGenerator) inlineSchema(schemaType reflect.Type) (*JSONSchema, error) {
+	switch {
+	case schemaType == timeType:
+		return &JSONSchema{Type: "string", Format: "date-time"}, nil
+	case isUUIDType(schemaType):
+		return &JSONSchema{Type: "string", Format: "uuid"}, nil
+	case schemaType == reflect.TypeOf(json.RawMessage{}):
+		return &JSONSchema{}, nil
+	case isStringSchemaType(schemaType):
+		return &JSONSchema{Type: "string"}, nil
+	}
+	switch schemaType.Kind() {
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &JSONSchema{Type: "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		minimum := 0.0
+		return &JSONSchema{Type: "integer", Minimum: &minimum}, nil
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}, nil
+	case reflect.String:
+		return &JSONSchema{Type: "string"}, nil
+	case reflect.Interface:
+		return &JSONSchema{}, nil
+	case reflect.Ptr:
+		return generator.schemaOf(schemaType.Elem())
+	case reflect.Slice, reflect.Array:
+		if schemaType.Kind() == reflect.Slice && schemaType.Elem().Kind() == reflect.Uint8 {
+			// []byte is encoded as a base64 string
+			return &JSONSchema{Type: "string", ContentEncoding: "base64"}, nil
+		}
+		items, err := generator.schemaOf(schemaType.Elem())
+		if err != nil {
+			return nil, err
+		}
+		schema := &JSONSchema{Type: "array", Items: items}
+		if schemaType.Kind() == reflect.Array {
+			length := schemaType.Len()
+			schema.MinItems = &length
+			schema.MaxItems = &length
+		}
+		return schema, nil
+	case reflect.Map:
+		keyKind := schemaType.Key().Kind()
+		if keyKind != reflect.String && !isStringSchemaType(schemaType.Key()) &&
+			(keyKind < reflect.Int || keyKind > reflect.Uintptr) {
+			return nil, fmt.Errorf("unsupported map key type [%s], JSON object keys must be strings or integers",
+				typeName(schemaType.Key()))
+		}
+		additionalProperties, err := generator.schemaOf(schemaType.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &JSONSchema{Type: "object", AdditionalProperties: additionalProperties}, nil
+	case reflect.Struct:
+		return generator.structSchema(schemaType)
+	default:
+		return nil, fmt.Errorf("unsupported type [%s], type cannot be encoded in JSON", typeName(schemaType))
+	}
+}
+
+// structSchema function returns the object schema of a structure.
+func (generator *schemaGenerator) structSchema(structType reflect.Type) (*JSONSchema, error) {
+	schema := &JSONSchema{Type: "object", Properties: make(map[string]*JSONSchema)}
+	for _, field := range jsonFields(structType) {
+		var property *JSONSchema
+		var err error
+		if field.asString {
+			property = &JSONSchema{Type: "string"}
+		} else if property, err = generator.schemaOf(field.field.Type); err != nil {
+			return nil, fmt.Errorf("[%s.%s] field: %w", typeName(structType), field.field.Name, err)
+		}
+		validateTag := field.field.Tag.Get("validate")
+		if enum := schemaEnum(validateTag, field.field.Type); len(enum) > 0 && len(property.Ref) == 0 {
+			property.Enum = enum
+		}
+		schema.Properties[field.name] = property
+		if hasTagOption(validateTag, "required") ||
+			(!field.omitEmpty && field.field.Type.Kind() != reflect.Ptr) {
+			schema.Required = append(schema.Required, field.name)
+		}
+	}
+	sort.Strings(schema.Required)
+	return schema, nil
+}
+
+// jsonFields function returns the fields of a structure encoded by encoding/json.
+//
+// Fields of embedded structures without name are promoted, index is the field index path from structType
+// (see fieldCollector.collect function for cycles and name conflicts).
+func jsonFields(structType reflect.Type) []jsonField {
+	collector := fieldCollector{
+		tagKey:      "json",
+		defaultName: func(fieldName string) string { return fieldName },
+		isStruct:    func(reflect.Type) bool { return true },
+	}
+	var fields []jsonField
+	for _, field := range collector.collect(structType) {
+		fields = append(fields, jsonField{
+			name:      field.name,
+			field:     field.field,
+			index:     field.index,
+			omitEmpty: hasTagOption(field.tagOptions, "omitempty"),
+			asString:  hasTagOption(field.tagOptions, "string"),
+		})
+	}
+	return fields
+}
+
+// schemaEnum function returns enum values defined by `validate:"oneof=a b c"` tag.
+func schemaEnum(validateTag string, fieldType reflect.Type) []any {
+	for _, rule := range strings.Split(validateTag, ",") {
+		values, found := strings.CutPrefix(strings.TrimSpace(rule), "oneof=")
+		if !found {
+			continue
+		}
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		var enum []any
+		for _, text := range strings.Fields(values) {
+			enumValue := reflect.New(fieldType).Elem()
+			if err := setStringToReflectValue(enumValue, text); err != nil {
+				enum = append(enum, text)
+				continue
+			}
+			enum = append(enum, enumValue.Interface())
+		}
+		return enum
+	}
+	return nil
+}
+
+// isUUIDType function returns true if type is a UUID ([16]byte array named "...UUID").
+func isUUIDType(schemaType reflect.Type) bool {
+	return strings.HasSuffix(schemaType.Name(), "UUID") && schemaType.Kind() == reflect.Array &&
+		schemaType.Len() == 16 && schemaType.Elem().Kind() == reflect.Uint8
+}
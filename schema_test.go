@@ -0,0 +1,158 @@
+package bvmgo_reflect
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testSchemaUUID [16]byte
+
+type testSchemaAddress struct {
+	City    string `json:"city"`
+	ZipCode string `json:"zipCode,omitempty"`
+}
+
+type testSchemaNode struct {
+	Name     string            `json:"name"`
+	Children []*testSchemaNode `json:"children,omitempty"`
+}
+
+type testSchemaConfig struct {
+	ID       testSchemaUUID     `json:"id"`
+	Name     string             `json:"name" validate:"required"`
+	Level    string             `json:"level,omitempty" validate:"oneof=debug info error"`
+	Port     uint16             `json:"port"`
+	Ratio    *float64           `json:"ratio"`
+	Created  time.Time          `json:"created"`
+	Home     testSchemaAddress  `json:"home"`
+	Work     *testSchemaAddress `json:"work,omitempty"`
+	Labels   map[string]string  `json:"labels,omitempty"`
+	Data     []byte             `json:"data,omitempty"`
+	Tree     testSchemaNode     `json:"tree"`
+	Count    int64              `json:"count,string"`
+	Ignored  string             `json:"-"`
+	internal string
+}
+
+func TestSchema_struct(t *testing.T) {
+	schema, err := Schema(reflect.TypeOf(testSchemaConfig{}))
+	if err != nil {
+		t.Errorf("Schema(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if schema.Schema != JSONSchemaDraft || schema.Type != "object" {
+		t.Errorf("Schema(...) = [%s %s], want [%s object]", schema.Schema, schema.Type, JSONSchemaDraft)
+	}
+	expectedRequired := []string{"count", "created", "home", "id", "name", "port", "tree"}
+	if !reflect.DeepEqual(schema.Required, expectedRequired) {
+		t.Errorf("Schema(...).Required = %v, want %v", schema.Required, expectedRequired)
+	}
+	if len(schema.Properties) != 12 {
+		t.Errorf("len(Schema(...).Properties) = %v, want %v", len(schema.Properties), 12)
+	}
+	tests := []struct {
+		property string
+		want     JSONSchema
+	}{
+		{property: "id", want: JSONSchema{Type: "string", Format: "uuid"}},
+		{property: "level", want: JSONSchema{Type: "string", Enum: []any{"debug", "info", "error"}}},
+		{property: "ratio", want: JSONSchema{Type: "number"}},
+		{property: "created", want: JSONSchema{Type: "string", Format: "date-time"}},
+		{property: "home", want: JSONSchema{Ref: "#/$defs/bvmgo_reflect.testSchemaAddress"}},
+		{property: "work", want: JSONSchema{Ref: "#/$defs/bvmgo_reflect.testSchemaAddress"}},
+		{property: "labels", want: JSONSchema{Type: "object", AdditionalProperties: &JSONSchema{Type: "string"}}},
+		{property: "data", want: JSONSchema{Type: "string", ContentEncoding: "base64"}},
+		{property: "count", want: JSONSchema{Type: "string"}},
+	}
+	for _, tt := range tests {
+		if got := schema.Properties[tt.property]; got == nil || !reflect.DeepEqual(*got, tt.want) {
+			t.Errorf("Schema(...).Properties[%s] = %+v, want %+v", tt.property, got, tt.want)
+		}
+	}
+	if _, ok := schema.Defs["bvmgo_reflect.testSchemaAddress"]; !ok {
+		t.Errorf("Schema(...).Defs = %v, want contain [%v]", schema.Defs, "bvmgo_reflect.testSchemaAddress")
+	}
+}
+
+func TestSchema_recursive(t *testing.T) {
+	schema, err := Schema(reflect.TypeOf(&testSchemaConfig{}))
+	if err != nil {
+		t.Errorf("Schema(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	node := schema.Defs["bvmgo_reflect.testSchemaNode"]
+	if node == nil {
+		t.Errorf("Schema(...).Defs = %v, want contain [%v]", schema.Defs, "bvmgo_reflect.testSchemaNode")
+		return
+	}
+	if node.Properties["children"].Items.Ref != "#/$defs/bvmgo_reflect.testSchemaNode" {
+		t.Errorf("children items = %+v, want a reference to node", node.Properties["children"].Items)
+	}
+	rootSchema, err := Schema(reflect.TypeOf(testSchemaNode{}))
+	if err != nil {
+		t.Errorf("Schema(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if rootSchema.Properties["children"].Items.Ref != "#" {
+		t.Errorf("children items = %+v, want a reference to root", rootSchema.Properties["children"].Items)
+	}
+	if _, err = json.Marshal(schema); err != nil {
+		t.Errorf("json.Marshal(...) returns \"%v\" error, want no error", err)
+	}
+}
+
+type testSchemaEmbeddedNode struct {
+	*testSchemaEmbeddedNode
+	Name string `json:"name"`
+}
+
+func TestSchema_selfEmbeddedPointer(t *testing.T) {
+	schema, err := Schema(reflect.TypeOf(testSchemaEmbeddedNode{}))
+	if err != nil {
+		t.Errorf("Schema(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if len(schema.Properties) != 1 || schema.Properties["name"] == nil {
+		t.Errorf("Schema(...).Properties = %v, want only [name] property", schema.Properties)
+	}
+}
+
+type testSchemaGeneric[T any] struct {
+	Value T `json:"value"`
+}
+
+type testSchemaGenericPair struct {
+	First  testSchemaGeneric[url.Userinfo] `json:"first"`
+	Second testSchemaGeneric[url.Userinfo] `json:"second"`
+}
+
+func TestSchema_genericDefinition(t *testing.T) {
+	schema, err := Schema(reflect.TypeOf(testSchemaGenericPair{}))
+	if err != nil {
+		t.Errorf("Schema(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	name := "bvmgo_reflect.testSchemaGeneric[net/url.Userinfo]"
+	if schema.Defs[name] == nil {
+		t.Errorf("Schema(...).Defs = %v, want contain [%v]", schema.Defs, name)
+	}
+	expected := "#/$defs/bvmgo_reflect.testSchemaGeneric%5Bnet~1url.Userinfo%5D"
+	if ref := schema.Properties["first"].Ref; ref != expected {
+		t.Errorf("first property reference = %v, want %v", ref, expected)
+	}
+}
+
+func TestSchema_unsupportedType(t *testing.T) {
+	_, err := Schema(reflect.TypeOf(struct{ Callback func() }{}))
+	if err == nil {
+		t.Errorf("Schema(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "type cannot be encoded in JSON") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "type cannot be encoded in JSON")
+	}
+}
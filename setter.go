@@ -1,9 +1,14 @@
 package bvmgo_reflect
 
 import (
+	"encoding"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 )
@@ -35,49 +40,159 @@ func SetValue[T any](targetPointer any, value T) error {
 //   - fieldName is not found on targetPointer structure,
 //   - value type is incompatible with structure field type.
 func SetField[T any](targetStructurePointer any, fieldName string, value T) error {
+	fieldValue, structType, err := findSettableField(targetStructurePointer, fieldName)
+	if err != nil {
+		return err
+	}
+	if err := setValueToReflectValue(fieldValue, value); err != nil {
+		return fmt.Errorf("[%s.%s] field cannot be set with current value: %w",
+			typeName(structType), strings.TrimSpace(fieldName), err)
+	}
+	return nil
+}
+
+// SetFieldWith function assigns the value to the field of structure pointer with options.
+//
+// If options.UseAccessors is true and structure has no exported field named fieldName,
+// the value is assigned with a "SetX(value)" setter method.
+//
+// See SetField function.
+func SetFieldWith[T any](targetStructurePointer any, fieldName string, value T, options FieldOptions) error {
+	if options.UseAccessors {
+		if found, err := callSetter(targetStructurePointer, fieldName, value); found {
+			return err
+		}
+	}
+	return SetField(targetStructurePointer, fieldName, value)
+}
+
+// SetFieldFromString function parses the text and assigns it to the field of structure pointer.
+//
+// Text is parsed according to field type: string, boolean, integer, unsigned integer, float, complex,
+// duration (time.Duration) or any type implementing encoding.TextUnmarshaler.
+// An empty text assigns nil to pointer fields.
+//
+// SetFieldFromString function returns an error if:
+//   - targetPointer is not a pointer,
+//   - fieldName is not a valid field name,
+//   - fieldName is not found on targetPointer structure,
+//   - text cannot be parsed to structure field type.
+func SetFieldFromString(targetStructurePointer any, fieldName string, text string) error {
+	fieldValue, structType, err := findSettableField(targetStructurePointer, fieldName)
+	if err != nil {
+		return err
+	}
+	if err := setStringToReflectValue(fieldValue, text); err != nil {
+		return fmt.Errorf("[%s.%s] field cannot be set with [%s] text: %w",
+			typeName(structType), strings.TrimSpace(fieldName), text, err)
+	}
+	return nil
+}
+
+// SetFields function assigns the values to the fields of structure pointer, all or nothing.
+//
+// Values are assigned in field names order with SetField function. If any assignment fails,
+// the previously assigned fields (and the values pointed by pointer fields) are restored.
+//
+// SetFields function returns all the errors returned by SetField function, joined with errors.Join.
+func SetFields(targetStructurePointer any, values map[string]any) error {
+	type savedField struct {
+		field   reflect.Value
+		old     reflect.Value
+		pointee reflect.Value
+	}
+	fieldNames := make([]string, 0, len(values))
+	for fieldName := range values {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	slices.Sort(fieldNames)
+	var saved []savedField
+	var errs []error
+	for _, fieldName := range fieldNames {
+		fieldValue, _, err := findSettableField(targetStructurePointer, fieldName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		// Save field value (and pointed value, SetField may assign a value to the pointed value)
+		field := savedField{field: fieldValue, old: reflect.New(fieldValue.Type()).Elem()}
+		field.old.Set(fieldValue)
+		if fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+			field.pointee = reflect.New(fieldValue.Type().Elem()).Elem()
+			field.pointee.Set(fieldValue.Elem())
+		}
+		saved = append(saved, field)
+		if err = SetField(targetStructurePointer, fieldName, values[fieldName]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	// Rollback in reverse order
+	for index := len(saved) - 1; index >= 0; index-- {
+		if saved[index].pointee.IsValid() {
+			saved[index].old.Elem().Set(saved[index].pointee)
+		}
+		saved[index].field.Set(saved[index].old)
+	}
+	return errors.Join(errs...)
+}
+
+// findSettableField function returns the "fieldName" field of structure pointer and structure type.
+//
+// findSettableField function returns an error if:
+//   - targetPointer is not a pointer,
+//   - fieldName is not a valid field name,
+//   - fieldName is not found on targetPointer structure,
+//   - fieldName is private or read only.
+func findSettableField(targetStructurePointer any, fieldName string) (fieldValue reflect.Value, structType reflect.Type, err error) {
 	cleanFieldName := strings.TrimSpace(fieldName)
 	ptrTarget := reflect.ValueOf(targetStructurePointer)
 	// Check is not null
 	if !ptrTarget.IsValid() || (ptrTarget.Kind() == reflect.Ptr && ptrTarget.IsNil()) {
-		return fmt.Errorf("a not nil pointer is required to set value to [%s] field",
+		err = fmt.Errorf("a not nil pointer is required to set value to [%s] field",
 			cleanFieldName)
+		return
 	}
 	// Check is a pointer
 	if ptrTarget.Kind() != reflect.Ptr {
-		return fmt.Errorf("unsupported type [%s], a pointer to a structure is required to set value to [%s] field",
+		err = fmt.Errorf("unsupported type [%s], a pointer to a structure is required to set value to [%s] field",
 			typeName(ptrTarget.Type()), cleanFieldName)
+		return
 	}
 	targetElem := ptrTarget.Elem()
 	// Check is a structure
 	if targetElem.Kind() != reflect.Struct {
-		return fmt.Errorf("unsupported type [%s], a pointer to a structure is required to set value to [%s] field",
+		err = fmt.Errorf("unsupported type [%s], a pointer to a structure is required to set value to [%s] field",
 			typeName(ptrTarget.Type()), cleanFieldName)
+		return
 	}
+	structType = targetElem.Type()
 	// Check field name
 	if len(strings.TrimSpace(cleanFieldName)) == 0 {
-		return fmt.Errorf("field name is empty")
+		err = fmt.Errorf("field name is empty")
+		return
 	}
-	fieldValue := targetElem.FieldByName(cleanFieldName)
+	fieldValue = targetElem.FieldByName(cleanFieldName)
 	// Check field exists
 	if !fieldValue.IsValid() {
-		return fmt.Errorf("[%s.%s] field is not found",
-			typeName(targetElem.Type()), cleanFieldName)
+		err = fmt.Errorf("[%s.%s] field is not found",
+			typeName(structType), cleanFieldName)
+		return
 	}
 	if !fieldValue.CanSet() {
 		firstRune, _ := utf8.DecodeRuneInString(cleanFieldName)
 		if unicode.IsLower(firstRune) {
-			return fmt.Errorf("[%s.%s] field is private",
-				typeName(targetElem.Type()), cleanFieldName)
+			err = fmt.Errorf("[%s.%s] field is private",
+				typeName(structType), cleanFieldName)
 		} else {
-			return fmt.Errorf("[%s.%s] field is read only",
-				typeName(targetElem.Type()), cleanFieldName)
+			err = fmt.Errorf("[%s.%s] field is read only",
+				typeName(structType), cleanFieldName)
 		}
+		return
 	}
-	if err := setValueToReflectValue(fieldValue, value); err != nil {
-		return fmt.Errorf("[%s.%s] field cannot be set with current value: %w",
-			typeName(targetElem.Type()), cleanFieldName, err)
-	}
-	return nil
+	return
 }
 
 // setValueToReflectValue function assigns the value to the target.
@@ -128,3 +243,76 @@ func findMatchType(target reflect.Value, valueType reflect.Value) (eltType refle
 	}
 	return
 }
+
+// durationType is the reflect.Type of time.Duration.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// textUnmarshalerType is the reflect.Type of encoding.TextUnmarshaler interface.
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// setStringToReflectValue function parses the text and assigns it to the target.
+//
+// setStringToReflectValue function returns an error if:
+//   - target type is not supported,
+//   - text cannot be parsed to target type.
+func setStringToReflectValue(target reflect.Value, text string) error {
+	// Pointer: empty text is nil, else create pointed value
+	if target.Kind() == reflect.Ptr {
+		if len(text) == 0 {
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		}
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return setStringToReflectValue(target.Elem(), text)
+	}
+	if target.CanAddr() && reflect.PointerTo(target.Type()).Implements(textUnmarshalerType) {
+		return target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+	}
+	if target.Type() == durationType {
+		duration, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		target.SetInt(int64(duration))
+		return nil
+	}
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(text)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(strings.TrimSpace(text))
+		if err != nil {
+			return err
+		}
+		target.SetBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(strings.TrimSpace(text), 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value, err := strconv.ParseUint(strings.TrimSpace(text), 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(strings.TrimSpace(text), target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetFloat(value)
+	case reflect.Complex64, reflect.Complex128:
+		value, err := strconv.ParseComplex(strings.TrimSpace(text), target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetComplex(value)
+	default:
+		return fmt.Errorf("variable type [%s] cannot be parsed from a string", typeName(target.Type()))
+	}
+	return nil
+}
@@ -542,3 +542,98 @@ func TestSetField_nil(t *testing.T) {
 		t.Errorf("testStruct.FieldPointer = [%v], want [%v]", testStruct.FieldPointer, nil)
 	}
 }
+
+func TestSetFieldFromString(t *testing.T) {
+	testStruct := testSetStruct{}
+	tests := []struct {
+		fieldName string
+		text      string
+	}{
+		{fieldName: "FieldString", text: "value"},
+		{fieldName: "FieldInt32", text: "-123"},
+		{fieldName: "FieldInt64", text: "1234567890123"},
+		{fieldName: "FieldFloat32", text: "1.5"},
+		{fieldName: "FieldFloat64", text: "2.25"},
+		{fieldName: "FieldBool", text: "true"},
+	}
+	for _, tt := range tests {
+		if err := SetFieldFromString(&testStruct, tt.fieldName, tt.text); err != nil {
+			t.Errorf("SetFieldFromString(%s) error = %v, want no Error", tt.fieldName, err)
+		}
+	}
+	expected := testSetStruct{FieldString: "value", FieldInt32: -123, FieldInt64: 1234567890123,
+		FieldFloat32: 1.5, FieldFloat64: 2.25, FieldBool: true}
+	if !reflect.DeepEqual(testStruct, expected) {
+		t.Errorf("SetFieldFromString() testStruct = %v, want = %v", testStruct, expected)
+	}
+}
+
+func TestSetFieldFromString_badText(t *testing.T) {
+	testStruct := testSetStruct{}
+	err := SetFieldFromString(&testStruct, "FieldInt32", "abc")
+	if err == nil {
+		t.Errorf("SetFieldFromString(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "field cannot be set with [abc] text") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "field cannot be set with [abc] text")
+	}
+}
+
+func TestSetFieldFromString_unsupportedType(t *testing.T) {
+	testStruct := testSetStruct{}
+	err := SetFieldFromString(&testStruct, "FieldSlice", "1,2")
+	if err == nil {
+		t.Errorf("SetFieldFromString(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "cannot be parsed from a string") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "cannot be parsed from a string")
+	}
+}
+
+func TestSetFields(t *testing.T) {
+	testStruct := testSetStruct{}
+	err := SetFields(&testStruct, map[string]any{
+		"FieldString":  "value",
+		"FieldInt32":   int32(5),
+		"FieldPointer": testSetSubStruct{Field1: 3},
+	})
+	if err != nil {
+		t.Errorf("SetFields(...) error = %v, want no Error", err)
+		return
+	}
+	expected := testSetStruct{FieldString: "value", FieldInt32: 5, FieldPointer: &testSetSubStruct{Field1: 3}}
+	if report := Diff(testStruct, expected, IgnorePaths("FieldFunc")); !report.Equal() {
+		t.Errorf("SetFields(...) differs from expected:\n%s", report)
+	}
+}
+
+func TestSetFields_rollback(t *testing.T) {
+	pointer := &testSetSubStruct{Field1: 1}
+	testStruct := testSetStruct{FieldString: "original", FieldInt64: 7, FieldPointer: pointer}
+	err := SetFields(&testStruct, map[string]any{
+		"FieldString":  "value",
+		"FieldInt64":   "not an int",
+		"FieldPointer": testSetSubStruct{Field1: 2},
+		"FieldUnknown": 1,
+		"privateField": "private",
+	})
+	if err == nil {
+		t.Errorf("SetFields(...) returns nil (no error), want an error")
+		return
+	}
+	for _, want := range []string{
+		"[bvmgo_reflect.testSetStruct.FieldInt64] field cannot be set with current value",
+		"[bvmgo_reflect.testSetStruct.FieldUnknown] field is not found",
+		"[bvmgo_reflect.testSetStruct.privateField] field is private",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), want)
+		}
+	}
+	if testStruct.FieldString != "original" || testStruct.FieldInt64 != 7 ||
+		testStruct.FieldPointer != pointer || pointer.Field1 != 1 {
+		t.Errorf("SetFields(...) = %+v (pointed %+v), want rolled back fields", testStruct, *pointer)
+	}
+}
@@ -0,0 +1,224 @@
+package bvmgo_reflect
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// sqlMappingKey identifies a column mapping: a structure type and a columns set.
+type sqlMappingKey struct {
+	structType reflect.Type
+	columns    string
+}
+
+// sqlMappings caches column mappings (sqlMappingKey -> [][]int field index paths).
+var sqlMappings sync.Map
+
+// scannerType is the reflect.Type of sql.Scanner interface.
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// timeType is the reflect.Type of time.Time.
+var timeType = reflect.TypeOf(time.Time{})
+
+// ScanRows function reads all remaining rows and appends a structure per row to dest.
+//
+// dest must be a pointer to a slice of structures (or of pointers to structures).
+// See ScanRow function for columns mapping. Rows are not closed by ScanRows function.
+//
+// ScanRows function returns an error if:
+//   - dest is not a pointer to a slice of structures,
+//   - a row cannot be scanned (see ScanRow function),
+//   - rows iteration returns an error.
+func ScanRows(rows *sql.Rows, dest any) error {
+	destValue := reflect.ValueOf(dest)
+	// Check is not null
+	if !destValue.IsValid() || (destValue.Kind() == reflect.Ptr && destValue.IsNil()) {
+		return fmt.Errorf("a not nil pointer is required to scan rows")
+	}
+	// Check is a pointer to a slice
+	if destValue.Kind() != reflect.Ptr || destValue.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("unsupported type [%s], a pointer to a slice is required to scan rows",
+			typeName(destValue.Type()))
+	}
+	sliceValue := destValue.Elem()
+	elementType := sliceValue.Type().Elem()
+	structType := elementType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported type [%s], a pointer to a slice of structures is required to scan rows",
+			typeName(destValue.Type()))
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	mapping := sqlColumnMapping(structType, columns)
+	for rows.Next() {
+		structPointer := reflect.New(structType)
+		if err := scanRowWithMapping(rows, structPointer.Elem(), mapping); err != nil {
+			return err
+		}
+		if elementType.Kind() == reflect.Ptr {
+			sliceValue.Set(reflect.Append(sliceValue, structPointer))
+		} else {
+			sliceValue.Set(reflect.Append(sliceValue, structPointer.Elem()))
+		}
+	}
+	return rows.Err()
+}
+
+// ScanRow function scans the current row into the dest structure.
+//
+// Columns are mapped to fields by "db" tag (`db:"name"`), or by snake_case field name if no tag is defined.
+// Fields tagged `db:"-"` and unexported fields are ignored, unknown columns are ignored.
+// Fields of embedded structures are promoted and fields of nested structures are mapped
+// to columns prefixed with the nested structure name ("address_city" for Address.City).
+// Embedded and nested structure pointers are allocated if one of their columns is not NULL.
+// Like Go promoted fields, the shallowest field of a column wins, ambiguous columns are ignored.
+// NULL values are supported by pointer fields and sql.Scanner fields (sql.NullString...).
+//
+// ScanRow function returns an error if:
+//   - dest is not a pointer to a structure,
+//   - a column value cannot be assigned to its field.
+func ScanRow(rows *sql.Rows, dest any) error {
+	destValue := reflect.ValueOf(dest)
+	// Check is not null
+	if !destValue.IsValid() || (destValue.Kind() == reflect.Ptr && destValue.IsNil()) {
+		return fmt.Errorf("a not nil pointer is required to scan row")
+	}
+	// Check is a pointer to a structure
+	if destValue.Kind() != reflect.Ptr || destValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported type [%s], a pointer to a structure is required to scan row",
+			typeName(destValue.Type()))
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	mapping := sqlColumnMapping(destValue.Elem().Type(), columns)
+	return scanRowWithMapping(rows, destValue.Elem(), mapping)
+}
+
+// scanRowWithMapping function scans the current row into structValue fields.
+//
+// mapping contains a field index path per column (nil for ignored columns).
+// Fields of structure pointers are scanned into nullable holders: a nil structure pointer is allocated
+// only if one of its columns is not NULL.
+func scanRowWithMapping(rows *sql.Rows, structValue reflect.Value, mapping [][]int) error {
+	targets := make([]any, len(mapping))
+	holders := make(map[int]reflect.Value)
+	for columnIndex, fieldIndex := range mapping {
+		if fieldIndex == nil {
+			targets[columnIndex] = new(any)
+			continue
+		}
+		fieldType, throughPointer := sqlFieldType(structValue.Type(), fieldIndex)
+		if throughPointer {
+			holder := reflect.New(reflect.PointerTo(fieldType))
+			holders[columnIndex] = holder
+			targets[columnIndex] = holder.Interface()
+			continue
+		}
+		targets[columnIndex] = structValue.FieldByIndex(fieldIndex).Addr().Interface()
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return fmt.Errorf("[%s] structure cannot be scanned: %w", typeName(structValue.Type()), err)
+	}
+	for columnIndex, holder := range holders {
+		if holder.Elem().IsNil() {
+			// NULL column: zero value if the structure pointer exists
+			if fieldValue, err := structValue.FieldByIndexErr(mapping[columnIndex]); err == nil {
+				fieldValue.Set(reflect.Zero(fieldValue.Type()))
+			}
+			continue
+		}
+		fieldValue, err := fieldByIndexAlloc(structValue, mapping[columnIndex])
+		if err != nil {
+			return fmt.Errorf("[%s] structure cannot be scanned: %w", typeName(structValue.Type()), err)
+		}
+		fieldValue.Set(holder.Elem().Elem())
+	}
+	return nil
+}
+
+// sqlFieldType function returns the type of the field at index path, and true if the path goes through
+// a structure pointer.
+func sqlFieldType(structType reflect.Type, index []int) (reflect.Type, bool) {
+	currentType := structType
+	throughPointer := false
+	for position, fieldIndex := range index {
+		if position > 0 && currentType.Kind() == reflect.Ptr {
+			currentType = currentType.Elem()
+			throughPointer = true
+		}
+		currentType = currentType.Field(fieldIndex).Type
+	}
+	return currentType, throughPointer
+}
+
+// sqlColumnMapping function returns the field index path of each column (cached by type and columns).
+func sqlColumnMapping(structType reflect.Type, columns []string) [][]int {
+	key := sqlMappingKey{structType: structType, columns: strings.Join(columns, "\x00")}
+	if mapping, ok := sqlMappings.Load(key); ok {
+		return mapping.([][]int)
+	}
+	fields := sqlFields(structType)
+	mapping := make([][]int, len(columns))
+	for columnIndex, column := range columns {
+		fieldIndex, ok := fields[column]
+		if !ok {
+			fieldIndex, ok = fields[strings.ToLower(column)]
+		}
+		if ok {
+			mapping[columnIndex] = fieldIndex
+		}
+	}
+	sqlMappings.Store(key, mapping)
+	return mapping
+}
+
+// sqlFields function returns column name -> field index path of structType fields.
+func sqlFields(structType reflect.Type) map[string][]int {
+	collector := fieldCollector{
+		tagKey:      "db",
+		defaultName: toSnakeCase,
+		isStruct: func(structType reflect.Type) bool {
+			return structType != timeType && !reflect.PointerTo(structType).Implements(scannerType)
+		},
+		nestedSeparator: "_",
+	}
+	fields := make(map[string][]int)
+	for _, field := range collector.collect(structType) {
+		fields[field.name] = field.index
+	}
+	return fields
+}
+
+// toSnakeCase function converts a Go identifier to snake_case ("UserID" -> "user_id").
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+	for index, current := range runes {
+		if unicode.IsUpper(current) {
+			if index > 0 {
+				previous := runes[index-1]
+				nextIsLower := index+1 < len(runes) && unicode.IsLower(runes[index+1])
+				if unicode.IsLower(previous) || unicode.IsDigit(previous) ||
+					(unicode.IsUpper(previous) && nextIsLower) {
+					builder.WriteRune('_')
+				}
+			}
+			builder.WriteRune(unicode.ToLower(current))
+		} else {
+			builder.WriteRune(current)
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,237 @@
+package bvmgo_reflect
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testSQLDriver is an in-memory database/sql driver: each query returns the registered result.
+type testSQLDriver struct{}
+
+type testSQLResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+var testSQLResults = map[string]testSQLResult{}
+
+type testSQLConn struct{}
+
+type testSQLStmt struct {
+	query string
+}
+
+type testSQLRows struct {
+	result testSQLResult
+	index  int
+}
+
+func (testSQLDriver) Open(_ string) (driver.Conn, error) { return testSQLConn{}, nil }
+
+func (testSQLConn) Prepare(query string) (driver.Stmt, error) { return &testSQLStmt{query: query}, nil }
+func (testSQLConn) Close() error                              { return nil }
+func (testSQLConn) Begin() (driver.Tx, error)                 { return nil, fmt.Errorf("not supported") }
+
+func (stmt *testSQLStmt) Close() error  { return nil }
+func (stmt *testSQLStmt) NumInput() int { return -1 }
+func (stmt *testSQLStmt) Exec(_ []driver.Value) (driver.Result, error) {
+	return nil, fmt.Errorf("not supported")
+}
+func (stmt *testSQLStmt) Query(_ []driver.Value) (driver.Rows, error) {
+	result, ok := testSQLResults[stmt.query]
+	if !ok {
+		return nil, fmt.Errorf("unknown query [%s]", stmt.query)
+	}
+	return &testSQLRows{result: result}, nil
+}
+
+func (rows *testSQLRows) Columns() []string { return rows.result.columns }
+func (rows *testSQLRows) Close() error      { return nil }
+func (rows *testSQLRows) Next(dest []driver.Value) error {
+	if rows.index >= len(rows.result.rows) {
+		return io.EOF
+	}
+	copy(dest, rows.result.rows[rows.index])
+	rows.index++
+	return nil
+}
+
+func init() {
+	sql.Register("bvmgo_reflect_test", testSQLDriver{})
+	created := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	testSQLResults["users"] = testSQLResult{
+		columns: []string{"id", "user_name", "email", "nickname", "created_at", "address_city", "extra"},
+		rows: [][]driver.Value{
+			{int64(1), "alice", "alice@example.com", "al", created, "Paris", "x"},
+			{int64(2), "bob", nil, nil, created, nil, nil},
+		},
+	}
+	testSQLResults["bad"] = testSQLResult{
+		columns: []string{"id"},
+		rows:    [][]driver.Value{{"not a number"}},
+	}
+}
+
+type testSQLAddress struct {
+	City sql.NullString
+}
+
+type testSQLBase struct {
+	ID int64
+}
+
+type testSQLUser struct {
+	testSQLBase
+	Name      string         `db:"user_name"`
+	Email     sql.NullString `db:"email"`
+	Nickname  *string
+	CreatedAt time.Time
+	Address   testSQLAddress
+	Ignored   string `db:"-"`
+}
+
+// TestSQLBase is an exported alias: pointers to unexported embedded structures cannot be allocated.
+type TestSQLBase = testSQLBase
+
+type testSQLHome struct {
+	City string
+}
+
+type testSQLPointerUser struct {
+	*TestSQLBase
+	Name    string `db:"user_name"`
+	Address *testSQLHome
+}
+
+func testSQLQuery(t *testing.T, query string) (*sql.DB, *sql.Rows) {
+	db, err := sql.Open("bvmgo_reflect_test", "")
+	if err != nil {
+		t.Fatalf("sql.Open(...) returns \"%v\" error, want no error", err)
+	}
+	rows, err := db.Query(query)
+	if err != nil {
+		t.Fatalf("db.Query(...) returns \"%v\" error, want no error", err)
+	}
+	return db, rows
+}
+
+func TestScanRows(t *testing.T) {
+	db, rows := testSQLQuery(t, "users")
+	defer db.Close()
+	defer rows.Close()
+	var users []testSQLUser
+	if err := ScanRows(rows, &users); err != nil {
+		t.Errorf("ScanRows(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	nickname := "al"
+	created := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	expected := []testSQLUser{
+		{testSQLBase: testSQLBase{ID: 1}, Name: "alice", Email: sql.NullString{String: "alice@example.com", Valid: true},
+			Nickname: &nickname, CreatedAt: created, Address: testSQLAddress{City: sql.NullString{String: "Paris", Valid: true}}},
+		{testSQLBase: testSQLBase{ID: 2}, Name: "bob", CreatedAt: created},
+	}
+	if report := Diff(users, expected); !report.Equal() {
+		t.Errorf("ScanRows(...) differs from expected:\n%s", report)
+	}
+}
+
+func TestScanRows_pointers(t *testing.T) {
+	db, rows := testSQLQuery(t, "users")
+	defer db.Close()
+	defer rows.Close()
+	var users []*testSQLUser
+	if err := ScanRows(rows, &users); err != nil {
+		t.Errorf("ScanRows(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if len(users) != 2 || users[1].Name != "bob" {
+		t.Errorf("ScanRows(...) = [%+v], want 2 users", users)
+	}
+}
+
+func TestScanRows_structurePointers(t *testing.T) {
+	db, rows := testSQLQuery(t, "users")
+	defer db.Close()
+	defer rows.Close()
+	var users []testSQLPointerUser
+	if err := ScanRows(rows, &users); err != nil {
+		t.Errorf("ScanRows(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	expected := []testSQLPointerUser{
+		{TestSQLBase: &TestSQLBase{ID: 1}, Name: "alice", Address: &testSQLHome{City: "Paris"}},
+		{TestSQLBase: &TestSQLBase{ID: 2}, Name: "bob"},
+	}
+	if report := Diff(users, expected); !report.Equal() {
+		t.Errorf("ScanRows(...) differs from expected:\n%s", report)
+	}
+}
+
+func TestScanRow(t *testing.T) {
+	db, rows := testSQLQuery(t, "users")
+	defer db.Close()
+	defer rows.Close()
+	if !rows.Next() {
+		t.Errorf("rows.Next() = false, want true")
+		return
+	}
+	var user testSQLUser
+	if err := ScanRow(rows, &user); err != nil {
+		t.Errorf("ScanRow(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if user.ID != 1 || user.Name != "alice" {
+		t.Errorf("ScanRow(...) = [%+v], want alice user", user)
+	}
+}
+
+func TestScanRows_badValue(t *testing.T) {
+	db, rows := testSQLQuery(t, "bad")
+	defer db.Close()
+	defer rows.Close()
+	var users []testSQLUser
+	err := ScanRows(rows, &users)
+	if err == nil {
+		t.Errorf("ScanRows(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "structure cannot be scanned") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "structure cannot be scanned")
+	}
+}
+
+func TestScanRows_notSlice(t *testing.T) {
+	db, rows := testSQLQuery(t, "users")
+	defer db.Close()
+	defer rows.Close()
+	var user testSQLUser
+	err := ScanRows(rows, &user)
+	if err == nil {
+		t.Errorf("ScanRows(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "a pointer to a slice is required") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "a pointer to a slice is required")
+	}
+}
+
+func TestToSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Name":       "name",
+		"UserID":     "user_id",
+		"HTTPServer": "http_server",
+		"CreatedAt":  "created_at",
+		"Address2":   "address2",
+	}
+	for name, want := range tests {
+		if got := toSnakeCase(name); got != want {
+			t.Errorf("toSnakeCase(%s) = %v, want %v", name, got, want)
+		}
+	}
+}
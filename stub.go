@@ -0,0 +1,259 @@
+package bvmgo_reflect
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// StubTestingT is the subset of testing.TB used by Stub assertions.
+type StubTestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Stub records calls of the function fields of a structure created by NewStub function,
+// and returns programmed results.
+type Stub struct {
+	mutex       sync.Mutex
+	pointer     any
+	structType  reflect.Type
+	fields      map[string]reflect.Type
+	results     map[string][]reflect.Value
+	onceResults map[string][][]reflect.Value
+	calls       map[string][][]any
+}
+
+// stubs maps structure pointers created by NewStub function to their Stub.
+var stubs sync.Map
+
+// NewStub function returns a pointer to a new T structure whose exported function fields are recording stubs.
+//
+// Stubs return zero values until results are programmed with Stub.Returns function.
+// The Stub is registered: use StubOf function to get the Stub of the structure, and Stub.Close function to release it
+// (for instance with t.Cleanup(StubOf(service).Close)). NewStubWith function returns a Stub that is not registered.
+//
+// NewStub function panics if T is not a structure.
+func NewStub[T any]() *T {
+	stubPointer, stub := NewStubWith[T]()
+	stubs.Store(stubPointer, stub)
+	return stubPointer
+}
+
+// NewStubWith function returns a pointer to a new T structure whose exported function fields are recording stubs,
+// with its Stub. Unlike NewStub function, the Stub is not registered (StubOf function returns nil) and needs no release.
+//
+// NewStubWith function panics if T is not a structure.
+func NewStubWith[T any]() (*T, *Stub) {
+	stubPointer := new(T)
+	structType := reflect.TypeOf(stubPointer).Elem()
+	if structType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("unsupported type [%s], a structure is required to create a stub", typeName(structType)))
+	}
+	stub := &Stub{
+		pointer:     stubPointer,
+		structType:  structType,
+		fields:      make(map[string]reflect.Type),
+		results:     make(map[string][]reflect.Value),
+		onceResults: make(map[string][][]reflect.Value),
+		calls:       make(map[string][][]any),
+	}
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		if !field.IsExported() || field.Type.Kind() != reflect.Func {
+			continue
+		}
+		stub.fields[field.Name] = field.Type
+		if err := SetField(stubPointer, field.Name, stub.makeFunction(field.Name, field.Type).Interface()); err != nil {
+			panic(err.Error())
+		}
+	}
+	return stubPointer, stub
+}
+
+// StubOf function returns the Stub of a structure pointer created by NewStub function (nil if not a stub).
+func StubOf(stubPointer any) *Stub {
+	if stub, ok := stubs.Load(stubPointer); ok {
+		return stub.(*Stub)
+	}
+	return nil
+}
+
+// makeFunction function returns a function recording its calls and returning programmed results.
+func (stub *Stub) makeFunction(fieldName string, functionType reflect.Type) reflect.Value {
+	return reflect.MakeFunc(functionType, func(argumentValues []reflect.Value) []reflect.Value {
+		arguments := make([]any, len(argumentValues))
+		for index, argumentValue := range argumentValues {
+			arguments[index] = argumentValue.Interface()
+		}
+		stub.mutex.Lock()
+		defer stub.mutex.Unlock()
+		stub.calls[fieldName] = append(stub.calls[fieldName], arguments)
+		if queue := stub.onceResults[fieldName]; len(queue) > 0 {
+			stub.onceResults[fieldName] = queue[1:]
+			return queue[0]
+		}
+		if results, ok := stub.results[fieldName]; ok {
+			return results
+		}
+		results := make([]reflect.Value, functionType.NumOut())
+		for index := range results {
+			results[index] = reflect.Zero(functionType.Out(index))
+		}
+		return results
+	})
+}
+
+// resultValues function converts results to the result types of the "fieldName" function field.
+func (stub *Stub) resultValues(fieldName string, results []any) ([]reflect.Value, error) {
+	functionType, ok := stub.fields[fieldName]
+	if !ok {
+		return nil, fmt.Errorf("[%s.%s] function field is not found", typeName(stub.structType), fieldName)
+	}
+	if len(results) != functionType.NumOut() {
+		return nil, fmt.Errorf("[%s.%s] function returns %d results, got %d",
+			typeName(stub.structType), fieldName, functionType.NumOut(), len(results))
+	}
+	resultValues := make([]reflect.Value, len(results))
+	for index, result := range results {
+		resultValue, err := stubValue(functionType.Out(index), result)
+		if err != nil {
+			return nil, fmt.Errorf("[%s.%s] function result [%d] cannot be set with current value: %w",
+				typeName(stub.structType), fieldName, index, err)
+		}
+		resultValues[index] = resultValue
+	}
+	return resultValues, nil
+}
+
+// stubValue function returns value as a valueType value: numbers are converted to other number types
+// (if they are not overflowed), else see setValueToReflectValue function.
+func stubValue(valueType reflect.Type, value any) (reflect.Value, error) {
+	result := reflect.New(valueType).Elem()
+	source := reflect.ValueOf(value)
+	if source.IsValid() && !source.Type().AssignableTo(valueType) && isNumberType(source.Type()) && isNumberType(valueType) {
+		converted := source.Convert(valueType)
+		if !converted.Convert(source.Type()).Equal(source) {
+			return result, fmt.Errorf("value [%v] overflows [%s] type", value, typeName(valueType))
+		}
+		result.Set(converted)
+		return result, nil
+	}
+	err := setValueToReflectValue(result, value)
+	return result, err
+}
+
+// expectedArguments function converts arguments to the parameter types of the "fieldName" function field
+// (variadic arguments are the last argument, as a slice). Arguments that cannot be converted are kept.
+func (stub *Stub) expectedArguments(fieldName string, arguments []any) []any {
+	functionType, ok := stub.fields[fieldName]
+	if !ok || functionType.NumIn() != len(arguments) {
+		return arguments
+	}
+	expected := make([]any, len(arguments))
+	for index, argument := range arguments {
+		expected[index] = argument
+		if argumentValue, err := stubValue(functionType.In(index), argument); err == nil {
+			expected[index] = argumentValue.Interface()
+		}
+	}
+	return expected
+}
+
+// Returns function programs the results returned by every call of the "fieldName" function field.
+// Numbers are converted to the number types of the results.
+//
+// Returns function returns an error if:
+//   - fieldName is not a function field,
+//   - results count or types do not match the function results.
+func (stub *Stub) Returns(fieldName string, results ...any) error {
+	cleanFieldName := strings.TrimSpace(fieldName)
+	resultValues, err := stub.resultValues(cleanFieldName, results)
+	if err != nil {
+		return err
+	}
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.results[cleanFieldName] = resultValues
+	return nil
+}
+
+// ReturnsOnce function programs the results returned by the next call of the "fieldName" function field.
+// Successive ReturnsOnce calls program successive calls, before results programmed by Returns function.
+//
+// See Returns function for errors.
+func (stub *Stub) ReturnsOnce(fieldName string, results ...any) error {
+	cleanFieldName := strings.TrimSpace(fieldName)
+	resultValues, err := stub.resultValues(cleanFieldName, results)
+	if err != nil {
+		return err
+	}
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.onceResults[cleanFieldName] = append(stub.onceResults[cleanFieldName], resultValues)
+	return nil
+}
+
+// Calls function returns the arguments of each call of the "fieldName" function field
+// (variadic arguments are the last argument, as a slice).
+func (stub *Stub) Calls(fieldName string) [][]any {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	calls := stub.calls[strings.TrimSpace(fieldName)]
+	return append([][]any(nil), calls...)
+}
+
+// CallCount function returns the number of calls of the "fieldName" function field.
+func (stub *Stub) CallCount(fieldName string) int {
+	return len(stub.Calls(fieldName))
+}
+
+// Reset function forgets recorded calls and programmed results.
+func (stub *Stub) Reset() {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.results = make(map[string][]reflect.Value)
+	stub.onceResults = make(map[string][][]reflect.Value)
+	stub.calls = make(map[string][][]any)
+}
+
+// Close function releases a Stub registered by NewStub function: StubOf function then returns nil
+// for its structure pointer.
+// The function fields of the structure still record calls and return programmed results.
+func (stub *Stub) Close() {
+	stubs.Delete(stub.pointer)
+}
+
+// AssertCalled function reports an error if the "fieldName" function field was never called with arguments
+// (converted to the parameter types, see Stub.Returns function).
+func (stub *Stub) AssertCalled(t StubTestingT, fieldName string, arguments ...any) bool {
+	t.Helper()
+	calls := stub.Calls(fieldName)
+	expected := stub.expectedArguments(strings.TrimSpace(fieldName), arguments)
+	for _, call := range calls {
+		if reflect.DeepEqual(call, expected) || (len(call) == 0 && len(arguments) == 0) {
+			return true
+		}
+	}
+	t.Errorf("[%s.%s] function was not called with arguments %v, calls = %v",
+		typeName(stub.structType), strings.TrimSpace(fieldName), arguments, calls)
+	return false
+}
+
+// AssertCallCount function reports an error if the "fieldName" function field was not called count times.
+func (stub *Stub) AssertCallCount(t StubTestingT, fieldName string, count int) bool {
+	t.Helper()
+	if callCount := stub.CallCount(fieldName); callCount != count {
+		t.Errorf("[%s.%s] function was called %d times, want %d",
+			typeName(stub.structType), strings.TrimSpace(fieldName), callCount, count)
+		return false
+	}
+	return true
+}
+
+// AssertNotCalled function reports an error if the "fieldName" function field was called.
+func (stub *Stub) AssertNotCalled(t StubTestingT, fieldName string) bool {
+	t.Helper()
+	return stub.AssertCallCount(t, fieldName, 0)
+}
@@ -0,0 +1,147 @@
+package bvmgo_reflect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testStubService struct {
+	Find    func(id int) (*testSetSubStruct, error)
+	Save    func(value testSetSubStruct) error
+	Log     func(format string, args ...any)
+	Count   func(id int64) uint8
+	Name    string
+	private func()
+}
+
+// testStubRecorder records StubTestingT errors.
+type testStubRecorder struct {
+	errors []string
+}
+
+func (recorder *testStubRecorder) Helper() {}
+
+func (recorder *testStubRecorder) Errorf(format string, args ...any) {
+	recorder.errors = append(recorder.errors, fmt.Sprintf(format, args...))
+}
+
+func TestNewStub(t *testing.T) {
+	service := NewStub[testStubService]()
+	stub := StubOf(service)
+	if stub == nil {
+		t.Errorf("StubOf(...) = nil, want a stub")
+		return
+	}
+	t.Cleanup(stub.Close)
+	if service.private != nil {
+		t.Errorf("service.private = a function, want nil")
+	}
+	// Zero values before programming
+	found, err := service.Find(1)
+	if found != nil || err != nil {
+		t.Errorf("service.Find(1) = [%v, %v], want [nil, nil]", found, err)
+	}
+	if err := stub.Returns("Find", testSetSubStruct{Field1: 42}, nil); err != nil {
+		t.Errorf("stub.Returns(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	_ = stub.ReturnsOnce("Find", nil, errors.New("not found"))
+	if _, err := service.Find(2); err == nil || err.Error() != "not found" {
+		t.Errorf("service.Find(2) error = %v, want [not found]", err)
+	}
+	if found, _ := service.Find(3); found == nil || found.Field1 != 42 {
+		t.Errorf("service.Find(3) = %v, want &{42}", found)
+	}
+	service.Log("%s=%d", "a", 1)
+	expectedCalls := [][]any{{1}, {2}, {3}}
+	if !reflect.DeepEqual(stub.Calls("Find"), expectedCalls) {
+		t.Errorf("stub.Calls(Find) = %v, want %v", stub.Calls("Find"), expectedCalls)
+	}
+	stub.AssertCalled(t, "Find", 2)
+	stub.AssertCalled(t, "Log", "%s=%d", []any{"a", 1})
+	stub.AssertCallCount(t, "Find", 3)
+	stub.AssertNotCalled(t, "Save")
+	stub.Reset()
+	stub.AssertNotCalled(t, "Find")
+}
+
+func TestStub_assertionFailures(t *testing.T) {
+	service, stub := NewStubWith[testStubService]()
+	_ = service.Save(testSetSubStruct{Field1: 1})
+	recorder := &testStubRecorder{}
+	stub.AssertCalled(recorder, "Save", testSetSubStruct{Field1: 2})
+	stub.AssertCallCount(recorder, "Save", 2)
+	stub.AssertNotCalled(recorder, "Save")
+	if len(recorder.errors) != 3 {
+		t.Errorf("recorder.errors = %v, want 3 errors", recorder.errors)
+		return
+	}
+	if !strings.Contains(recorder.errors[0], "[bvmgo_reflect.testStubService.Save] function was not called with arguments") {
+		t.Errorf("recorder.errors[0] = [%v], want contain [%v]", recorder.errors[0], "function was not called with arguments")
+	}
+	if recorder.errors[1] != "[bvmgo_reflect.testStubService.Save] function was called 1 times, want 2" {
+		t.Errorf("recorder.errors[1] = [%v], want [%v]", recorder.errors[1],
+			"[bvmgo_reflect.testStubService.Save] function was called 1 times, want 2")
+	}
+}
+
+func TestStub_returnsErrors(t *testing.T) {
+	_, stub := NewStubWith[testStubService]()
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "unknown field", err: stub.Returns("Name", "x"), want: "[bvmgo_reflect.testStubService.Name] function field is not found"},
+		{name: "results count", err: stub.Returns("Find", nil), want: "function returns 2 results, got 1"},
+		{name: "result type", err: stub.Returns("Save", "not an error"), want: "function result [0] cannot be set with current value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Errorf("returns nil (no error), want an error")
+				return
+			}
+			if !strings.Contains(tt.err.Error(), tt.want) {
+				t.Errorf("err.Error() = [%v], want contain [%v]", tt.err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestStub_Close(t *testing.T) {
+	service := NewStub[testStubService]()
+	stub := StubOf(service)
+	stub.Close()
+	if StubOf(service) != nil {
+		t.Errorf("StubOf(...) after Close() = a stub, want nil")
+	}
+	_ = stub.Returns("Save", errors.New("closed"))
+	if err := service.Save(testSetSubStruct{}); err == nil || err.Error() != "closed" {
+		t.Errorf("service.Save(...) error = %v, want [closed]", err)
+	}
+	stub.AssertCallCount(t, "Save", 1)
+}
+
+func TestNewStubWith(t *testing.T) {
+	service, stub := NewStubWith[testStubService]()
+	if StubOf(service) != nil {
+		t.Errorf("StubOf(...) = a stub, want nil")
+	}
+	if err := stub.Returns("Count", 3); err != nil {
+		t.Errorf("stub.Returns(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if count := service.Count(5); count != 3 {
+		t.Errorf("service.Count(5) = %v, want 3", count)
+	}
+	if !stub.AssertCalled(t, "Count", 5) {
+		t.Errorf("stub.AssertCalled(Count, 5) = false, want true")
+	}
+	if err := stub.Returns("Count", 300); err == nil || !strings.Contains(err.Error(), "value [300] overflows [uint8] type") {
+		t.Errorf("stub.Returns(Count, 300) error = [%v], want contain [%v]", err, "value [300] overflows [uint8] type")
+	}
+}
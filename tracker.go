@@ -0,0 +1,209 @@
+package bvmgo_reflect
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Change is a modification of a tracked structure field.
+type Change struct {
+	// Path is the dotted path of the field ("Name", "Address.City").
+	Path string
+	// Old is the field value when tracking started (or when tracker was reset).
+	Old any
+	// New is the current field value.
+	New any
+}
+
+// TrackOptions configures TrackWith function.
+type TrackOptions struct {
+	// RecordSetOnly disables the snapshot: only the changes made with Tracker.Set function are reported.
+	RecordSetOnly bool
+}
+
+// Tracker detects the changes of a structure.
+type Tracker struct {
+	options  TrackOptions
+	target   reflect.Value
+	snapshot reflect.Value
+	recorded []Change
+}
+
+// Track function returns a tracker of the structure pointed by targetStructurePointer.
+//
+// The tracker snapshots the structure (deep copy), Changed function reports the modified fields.
+//
+// Track function returns an error if targetStructurePointer is not a not nil pointer to a structure.
+func Track(targetStructurePointer any) (*Tracker, error) {
+	return TrackWith(targetStructurePointer, TrackOptions{})
+}
+
+// TrackWith function returns a tracker of the structure pointed by targetStructurePointer with options.
+//
+// See Track function.
+func TrackWith(targetStructurePointer any, options TrackOptions) (*Tracker, error) {
+	target := reflect.ValueOf(targetStructurePointer)
+	// Check is not null
+	if !target.IsValid() || (target.Kind() == reflect.Ptr && target.IsNil()) {
+		return nil, fmt.Errorf("a not nil pointer is required to track changes")
+	}
+	// Check is a pointer to a structure
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported type [%s], a pointer to a structure is required to track changes",
+			typeName(target.Type()))
+	}
+	tracker := &Tracker{options: options, target: target}
+	tracker.Reset()
+	return tracker, nil
+}
+
+// Reset function accepts the current changes: the structure is snapshot again and recorded changes are cleared.
+func (tracker *Tracker) Reset() {
+	tracker.recorded = nil
+	if !tracker.options.RecordSetOnly {
+		tracker.snapshot = deepCopy(tracker.target.Elem())
+	}
+}
+
+// Set function assigns value to the field at dotted path ("Name", "Address.City") with SetField function
+// and records the change.
+//
+// Set function returns an error if:
+//   - path contains a field which is not found, private, nil or not a structure,
+//   - SetField function returns an error.
+func (tracker *Tracker) Set(path string, value any) error {
+	parent, fieldName, err := tracker.resolveParent(path)
+	if err != nil {
+		return err
+	}
+	fieldValue, _, err := findSettableField(parent.Interface(), fieldName)
+	if err != nil {
+		return err
+	}
+	old := deepCopy(fieldValue).Interface()
+	if err = SetField(parent.Interface(), fieldName, value); err != nil {
+		return err
+	}
+	path = strings.TrimSpace(path)
+	for _, change := range tracker.recorded {
+		if change.Path == path {
+			// First old value is kept
+			return nil
+		}
+	}
+	tracker.recorded = append(tracker.recorded, Change{Path: path, Old: old})
+	return nil
+}
+
+// Changed function returns the changes since tracking started (or since tracker was reset).
+//
+// Nested structures (and not nil pointers to structures) fields are compared one by one,
+// other fields (including time.Time and encoding.TextMarshaler structures) are compared with Equal function.
+// If RecordSetOnly option is true, only the changes made with Set function are returned.
+func (tracker *Tracker) Changed() []Change {
+	var changes []Change
+	if tracker.options.RecordSetOnly {
+		for _, change := range tracker.recorded {
+			parent, fieldName, err := tracker.resolveParent(change.Path)
+			if err != nil {
+				continue
+			}
+			change.New = parent.Elem().FieldByName(fieldName).Interface()
+			if !Equal(change.Old, change.New, UseEqualMethods()) {
+				changes = append(changes, change)
+			}
+		}
+		return changes
+	}
+	visited := map[visitedPointer]bool{{pointer: tracker.target.Pointer(), pointerType: tracker.target.Type()}: true}
+	compareTracked("", tracker.snapshot, tracker.target.Elem(), visited, &changes)
+	return changes
+}
+
+// resolveParent function returns the pointer to the structure containing the field at dotted path.
+func (tracker *Tracker) resolveParent(path string) (parent reflect.Value, fieldName string, err error) {
+	names := strings.Split(strings.TrimSpace(path), ".")
+	parent = tracker.target
+	for _, name := range names[:len(names)-1] {
+		structType := parent.Elem().Type()
+		field := parent.Elem().FieldByName(name)
+		switch {
+		case !field.IsValid():
+			err = fmt.Errorf("[%s.%s] field is not found", typeName(structType), name)
+		case !field.CanInterface():
+			err = fmt.Errorf("[%s.%s] field is private", typeName(structType), name)
+		case field.Kind() == reflect.Struct:
+			parent = field.Addr()
+		case field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct:
+			if field.IsNil() {
+				err = fmt.Errorf("[%s.%s] field is nil", typeName(structType), name)
+			}
+			parent = field
+		default:
+			err = fmt.Errorf("[%s.%s] field is not a structure", typeName(structType), name)
+		}
+		if err != nil {
+			return
+		}
+	}
+	fieldName = names[len(names)-1]
+	return
+}
+
+// isTrackedStruct function returns true if the fields of a structure type are compared one by one.
+func isTrackedStruct(structType reflect.Type) bool {
+	if structType.Kind() != reflect.Struct || structType.Implements(textMarshalerType) ||
+		reflect.PointerTo(structType).Implements(textMarshalerType) {
+		return false
+	}
+	for index := 0; index < structType.NumField(); index++ {
+		if structType.Field(index).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// compareTracked function appends the changes between old and current structures to changes.
+func compareTracked(path string, old reflect.Value, current reflect.Value, visited map[visitedPointer]bool, changes *[]Change) {
+	structType := old.Type()
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		// Exported fields of unexported embedded structures are promoted
+		if !field.IsExported() && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		oldField, currentField := old.Field(index), current.Field(index)
+		fieldPath := path + field.Name
+		// Embedded structure fields are promoted
+		if field.Anonymous {
+			fieldPath = path
+		}
+		if field.Type.Kind() == reflect.Ptr && isTrackedStruct(field.Type.Elem()) && !oldField.IsNil() && !currentField.IsNil() {
+			key := visitedPointer{pointer: currentField.Pointer(), pointerType: currentField.Type()}
+			// Cycle: pointed structure is already compared
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+			oldField, currentField = oldField.Elem(), currentField.Elem()
+		}
+		if isTrackedStruct(oldField.Type()) {
+			if !field.Anonymous {
+				fieldPath += "."
+			}
+			compareTracked(fieldPath, oldField, currentField, visited, changes)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous {
+			fieldPath = path + field.Name
+		}
+		if !Equal(oldField.Interface(), currentField.Interface(), UseEqualMethods()) {
+			*changes = append(*changes, Change{Path: fieldPath, Old: oldField.Interface(), New: currentField.Interface()})
+		}
+	}
+}
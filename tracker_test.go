@@ -0,0 +1,116 @@
+package bvmgo_reflect
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testTrackBase struct {
+	ID int
+}
+
+type testTrackAddress struct {
+	City string
+	Zip  string
+}
+
+type testTrackUser struct {
+	testTrackBase
+	Name     string
+	Tags     []string
+	Address  testTrackAddress
+	Billing  *testTrackAddress
+	Updated  time.Time
+	Parent   *testTrackUser
+	internal int
+}
+
+func TestTrack(t *testing.T) {
+	user := &testTrackUser{testTrackBase: testTrackBase{ID: 1}, Name: "alice", Tags: []string{"a"},
+		Address: testTrackAddress{City: "Paris"}, Billing: &testTrackAddress{City: "Lyon"}}
+	user.Parent = user
+	tracker, err := Track(user)
+	if err != nil {
+		t.Errorf("Track(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if changes := tracker.Changed(); len(changes) != 0 {
+		t.Errorf("Changed() = %+v, want no change", changes)
+	}
+	updated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	user.ID = 2
+	user.Tags[0] = "b"
+	user.Address.City = "Nice"
+	user.Billing.Zip = "69000"
+	user.Updated = updated
+	user.internal = 3
+	expected := []Change{
+		{Path: "ID", Old: 1, New: 2},
+		{Path: "Tags", Old: []string{"a"}, New: []string{"b"}},
+		{Path: "Address.City", Old: "Paris", New: "Nice"},
+		{Path: "Billing.Zip", Old: "", New: "69000"},
+		{Path: "Updated", Old: time.Time{}, New: updated},
+	}
+	if report := Diff(tracker.Changed(), expected); !report.Equal() {
+		t.Errorf("Changed() differs from expected:\n%s", report)
+	}
+	tracker.Reset()
+	if changes := tracker.Changed(); len(changes) != 0 {
+		t.Errorf("Changed() after Reset() = %+v, want no change", changes)
+	}
+}
+
+func TestTracker_Set(t *testing.T) {
+	user := &testTrackUser{Name: "alice", Billing: &testTrackAddress{City: "Lyon"}}
+	tracker, err := TrackWith(user, TrackOptions{RecordSetOnly: true})
+	if err != nil {
+		t.Errorf("TrackWith(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	user.ID = 5 // Not recorded
+	if err = tracker.Set("Name", "bob"); err != nil {
+		t.Errorf("Set(Name) returns \"%v\" error, want no error", err)
+	}
+	if err = tracker.Set("Name", "carol"); err != nil {
+		t.Errorf("Set(Name) returns \"%v\" error, want no error", err)
+	}
+	if err = tracker.Set("Billing.City", "Nice"); err != nil {
+		t.Errorf("Set(Billing.City) returns \"%v\" error, want no error", err)
+	}
+	if err = tracker.Set("Address.Zip", ""); err != nil {
+		t.Errorf("Set(Address.Zip) returns \"%v\" error, want no error", err)
+	}
+	expected := []Change{
+		{Path: "Name", Old: "alice", New: "carol"},
+		{Path: "Billing.City", Old: "Lyon", New: "Nice"},
+	}
+	if report := Diff(tracker.Changed(), expected); !report.Equal() {
+		t.Errorf("Changed() differs from expected:\n%s", report)
+	}
+	tests := []struct {
+		path  string
+		value any
+		want  string
+	}{
+		{"Unknown", 1, "[bvmgo_reflect.testTrackUser.Unknown] field is not found"},
+		{"internal", 1, "[bvmgo_reflect.testTrackUser.internal] field is private"},
+		{"Parent.Name", "x", "[bvmgo_reflect.testTrackUser.Parent] field is nil"},
+		{"Name.Length", 1, "[bvmgo_reflect.testTrackUser.Name] field is not a structure"},
+		{"Name", 1, "[bvmgo_reflect.testTrackUser.Name] field cannot be set"},
+	}
+	for _, tt := range tests {
+		if err := tracker.Set(tt.path, tt.value); err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("Set(%s) returns \"%v\" error, want \"%s\" error", tt.path, err, tt.want)
+		}
+	}
+}
+
+func TestTrack_invalid(t *testing.T) {
+	if _, err := Track(testTrackUser{}); err == nil {
+		t.Errorf("Track(structure) returns no error, want error")
+	}
+	if _, err := Track((*testTrackUser)(nil)); err == nil {
+		t.Errorf("Track(nil) returns no error, want error")
+	}
+}
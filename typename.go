@@ -1,7 +1,11 @@
 package bvmgo_reflect
 
 import (
+	"path"
 	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
 )
 
 // TypeName return the name of type.
@@ -19,3 +23,278 @@ func typeName(elementType reflect.Type) string {
 	}
 	return elementType.String()
 }
+
+// TypeNameFormat defines how type names are rendered by TypeNameWith function.
+type TypeNameFormat int
+
+const (
+	// TypeNameDefault renders types like reflect.Type.String() ("pkg.Name").
+	TypeNameDefault TypeNameFormat = iota
+	// TypeNameFullPath renders types qualified with their full package path ("github.com/user/pkg.Name").
+	TypeNameFullPath
+	// TypeNameNoPackage renders types without package qualifier ("Name", "map[string]Name").
+	TypeNameNoPackage
+	// TypeNameShort renders types without package qualifier and without generic parameters ("List" for "pkg.List[int]").
+	TypeNameShort
+	// TypeNameGoSource renders types as Go source, qualified with package names or import aliases.
+	TypeNameGoSource
+)
+
+// TypeNameOptions configures TypeNameWith function.
+type TypeNameOptions struct {
+	// Format defines how type names are rendered.
+	Format TypeNameFormat
+	// ShortGenerics renders generic type parameters qualified with package names instead of full package paths
+	// (TypeNameDefault and TypeNameFullPath formats).
+	ShortGenerics bool
+	// ImportAliases maps import paths to their alias (TypeNameGoSource format). Packages not rendered yet
+	// are otherwise qualified with the name assumed from their import path ("yaml" for "gopkg.in/yaml.v3").
+	ImportAliases map[string]string
+	// LocalPackage is the import path of the package whose types are not qualified (TypeNameGoSource format).
+	LocalPackage string
+}
+
+// TypeNameWith function returns the name of value type with options.
+func TypeNameWith[T any](value T, options TypeNameOptions) string {
+	return FormatTypeName(reflect.TypeOf(value), options)
+}
+
+// TypeNameOf function returns the name of T type.
+//
+// Unlike TypeName function, TypeNameOf function does not require a value and returns interface types names.
+func TypeNameOf[T any]() string {
+	return typeName(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// TypeNameOfWith function returns the name of T type with options.
+func TypeNameOfWith[T any](options TypeNameOptions) string {
+	return FormatTypeName(reflect.TypeOf((*T)(nil)).Elem(), options)
+}
+
+// FormatTypeName function returns the name of a reflect.Type with options.
+func FormatTypeName(elementType reflect.Type, options TypeNameOptions) string {
+	if elementType == nil {
+		return "<nil>"
+	}
+	formatter := typeNameFormatter{options: options}
+	return formatter.format(elementType)
+}
+
+// typeNameFormatter renders type names.
+type typeNameFormatter struct {
+	options TypeNameOptions
+	// packageNames maps import paths to package names of types already rendered.
+	packageNames map[string]string
+	// onImport function, if defined, is called with each import path required by TypeNameGoSource format.
+	onImport func(importPath string)
+}
+
+// format function returns the name of a type.
+func (formatter *typeNameFormatter) format(elementType reflect.Type) string {
+	if elementType.Kind() == reflect.Invalid {
+		return "<invalid>"
+	}
+	if len(elementType.Name()) > 0 {
+		return formatter.formatNamed(elementType)
+	}
+	switch elementType.Kind() {
+	case reflect.Ptr:
+		return "*" + formatter.format(elementType.Elem())
+	case reflect.Slice:
+		return "[]" + formatter.format(elementType.Elem())
+	case reflect.Array:
+		return "[" + strconv.Itoa(elementType.Len()) + "]" + formatter.format(elementType.Elem())
+	case reflect.Map:
+		return "map[" + formatter.format(elementType.Key()) + "]" + formatter.format(elementType.Elem())
+	case reflect.Chan:
+		switch elementType.ChanDir() {
+		case reflect.RecvDir:
+			return "<-chan " + formatter.format(elementType.Elem())
+		case reflect.SendDir:
+			return "chan<- " + formatter.format(elementType.Elem())
+		default:
+			if elementType.Elem().Kind() == reflect.Chan && elementType.Elem().ChanDir() == reflect.RecvDir {
+				return "chan (" + formatter.format(elementType.Elem()) + ")"
+			}
+			return "chan " + formatter.format(elementType.Elem())
+		}
+	case reflect.Func:
+		return "func" + formatter.formatSignature(elementType)
+	case reflect.Interface:
+		if elementType.NumMethod() == 0 {
+			if formatter.options.Format == TypeNameGoSource {
+				return "any"
+			}
+			return "interface {}"
+		}
+		methods := make([]string, elementType.NumMethod())
+		for index := range methods {
+			method := elementType.Method(index)
+			methods[index] = method.Name + formatter.formatSignature(method.Type)
+		}
+		return "interface { " + strings.Join(methods, "; ") + " }"
+	case reflect.Struct:
+		if elementType.NumField() == 0 {
+			return "struct {}"
+		}
+		fields := make([]string, elementType.NumField())
+		for index := range fields {
+			field := elementType.Field(index)
+			if field.Anonymous {
+				fields[index] = formatter.format(field.Type)
+			} else {
+				fields[index] = field.Name + " " + formatter.format(field.Type)
+			}
+			if len(field.Tag) > 0 {
+				fields[index] += " " + strconv.Quote(string(field.Tag))
+			}
+		}
+		return "struct { " + strings.Join(fields, "; ") + " }"
+	default:
+		return elementType.String()
+	}
+}
+
+// formatSignature function returns the parameters and results of a function type ("(int, string) error").
+func (formatter *typeNameFormatter) formatSignature(functionType reflect.Type) string {
+	parameters := make([]string, functionType.NumIn())
+	for index := range parameters {
+		if functionType.IsVariadic() && index == len(parameters)-1 {
+			parameters[index] = "..." + formatter.format(functionType.In(index).Elem())
+		} else {
+			parameters[index] = formatter.format(functionType.In(index))
+		}
+	}
+	results := make([]string, functionType.NumOut())
+	for index := range results {
+		results[index] = formatter.format(functionType.Out(index))
+	}
+	signature := "(" + strings.Join(parameters, ", ") + ")"
+	if len(results) == 1 {
+		signature += " " + results[0]
+	} else if len(results) > 1 {
+		signature += " (" + strings.Join(results, ", ") + ")"
+	}
+	return signature
+}
+
+// formatNamed function returns the name of a named type.
+func (formatter *typeNameFormatter) formatNamed(elementType reflect.Type) string {
+	pkgPath := elementType.PkgPath()
+	name := elementType.Name()
+	// Generic type instantiation: type parameters are rendered with full package paths by reflect
+	baseName, genericArguments, isGeneric := strings.Cut(name, "[")
+	if isGeneric {
+		genericArguments = strings.TrimSuffix(genericArguments, "]")
+	}
+	if len(pkgPath) > 0 {
+		// Type.String() is qualified with the package name, which may differ from the last path element
+		packageName, _, _ := strings.Cut(elementType.String(), ".")
+		if formatter.packageNames == nil {
+			formatter.packageNames = make(map[string]string)
+		}
+		formatter.packageNames[pkgPath] = packageName
+	}
+	result := formatter.qualify(pkgPath, baseName)
+	if isGeneric {
+		switch formatter.options.Format {
+		case TypeNameShort:
+		case TypeNameFullPath, TypeNameDefault:
+			if formatter.options.ShortGenerics {
+				result += "[" + formatter.formatGenericArguments(genericArguments) + "]"
+			} else {
+				result += "[" + genericArguments + "]"
+			}
+		default:
+			result += "[" + formatter.formatGenericArguments(genericArguments) + "]"
+		}
+	}
+	return result
+}
+
+// qualify function returns the name of a type qualified according to format.
+func (formatter *typeNameFormatter) qualify(pkgPath string, name string) string {
+	if len(pkgPath) == 0 {
+		return name
+	}
+	switch formatter.options.Format {
+	case TypeNameFullPath:
+		return pkgPath + "." + name
+	case TypeNameNoPackage, TypeNameShort:
+		return name
+	case TypeNameGoSource:
+		if pkgPath == formatter.options.LocalPackage {
+			return name
+		}
+		if formatter.onImport != nil {
+			formatter.onImport(pkgPath)
+		}
+		if alias, ok := formatter.options.ImportAliases[pkgPath]; ok {
+			return alias + "." + name
+		}
+		return formatter.packageName(pkgPath) + "." + name
+	default:
+		return formatter.packageName(pkgPath) + "." + name
+	}
+}
+
+// packageName function returns the name of the package of import path.
+//
+// The name is known if a type of the package has already been rendered,
+// else it is assumed from the import path (see assumedPackageName function).
+func (formatter *typeNameFormatter) packageName(pkgPath string) string {
+	if packageName, ok := formatter.packageNames[pkgPath]; ok {
+		return packageName
+	}
+	return assumedPackageName(pkgPath)
+}
+
+// assumedPackageName function returns the package name assumed from an import path, like goimports:
+// the last element of the path (skipping a major version suffix such as "v2"), without "go-" prefix,
+// truncated at its first character that is not valid in an identifier ("yaml" for "gopkg.in/yaml.v3").
+//
+// The result is always a valid identifier, import aliases must be defined for packages whose name differs.
+func assumedPackageName(pkgPath string) string {
+	base := path.Base(pkgPath)
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil && path.Dir(pkgPath) != "." {
+			base = path.Base(path.Dir(pkgPath))
+		}
+	}
+	base = strings.TrimPrefix(base, "go-")
+	if end := strings.IndexFunc(base, func(character rune) bool {
+		return character != '_' && !unicode.IsLetter(character) && !unicode.IsDigit(character)
+	}); end >= 0 {
+		base = base[:end]
+	}
+	if len(base) == 0 || unicode.IsDigit(rune(base[0])) {
+		base = "_" + base
+	}
+	return base
+}
+
+// formatGenericArguments function rewrites the qualified names ("full/path.Name") of generic type parameters.
+func (formatter *typeNameFormatter) formatGenericArguments(arguments string) string {
+	var builder strings.Builder
+	isDelimiter := func(character rune) bool {
+		return strings.ContainsRune("[]*(), ;{}", character)
+	}
+	for len(arguments) > 0 {
+		tokenEnd := strings.IndexFunc(arguments, isDelimiter)
+		if tokenEnd == 0 {
+			builder.WriteByte(arguments[0])
+			arguments = arguments[1:]
+			continue
+		}
+		if tokenEnd < 0 {
+			tokenEnd = len(arguments)
+		}
+		token := arguments[:tokenEnd]
+		arguments = arguments[tokenEnd:]
+		if separator := strings.LastIndex(token, "."); separator > 0 {
+			token = formatter.qualify(token[:separator], token[separator+1:])
+		}
+		builder.WriteString(token)
+	}
+	return builder.String()
+}
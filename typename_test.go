@@ -50,3 +50,91 @@ func TestTypeName(t *testing.T) {
 		})
 	}
 }
+
+type testTypeNameGeneric[T any] struct {
+	value T
+}
+
+type testTypeNameInterface interface {
+	Name() string
+}
+
+func TestTypeNameOf(t *testing.T) {
+	if got := TypeNameOf[testTypeNameInterface](); got != "bvmgo_reflect.testTypeNameInterface" {
+		t.Errorf("TypeNameOf() = %v, want %v", got, "bvmgo_reflect.testTypeNameInterface")
+	}
+	if got := TypeNameOf[error](); got != "error" {
+		t.Errorf("TypeNameOf() = %v, want %v", got, "error")
+	}
+	if got := TypeNameOf[[]int](); got != "[]int" {
+		t.Errorf("TypeNameOf() = %v, want %v", got, "[]int")
+	}
+}
+
+func TestTypeNameWith(t *testing.T) {
+	generic := testTypeNameGeneric[*testTypeNameStructure]{}
+	tests := []struct {
+		name    string
+		arg     any
+		options TypeNameOptions
+		want    string
+	}{
+		{name: "default", arg: &testTypeNameStructure{}, options: TypeNameOptions{},
+			want: "*bvmgo_reflect.testTypeNameStructure"},
+		{name: "full path", arg: map[string]testTypeNameStructure{}, options: TypeNameOptions{Format: TypeNameFullPath},
+			want: "map[string]bvmgo-reflect.testTypeNameStructure"},
+		{name: "no package", arg: []*testTypeNameStructure{}, options: TypeNameOptions{Format: TypeNameNoPackage},
+			want: "[]*testTypeNameStructure"},
+		{name: "short", arg: generic, options: TypeNameOptions{Format: TypeNameShort},
+			want: "testTypeNameGeneric"},
+		{name: "generic default", arg: generic, options: TypeNameOptions{},
+			want: "bvmgo_reflect.testTypeNameGeneric[*bvmgo-reflect.testTypeNameStructure]"},
+		{name: "generic short parameters", arg: generic, options: TypeNameOptions{ShortGenerics: true},
+			want: "bvmgo_reflect.testTypeNameGeneric[*bvmgo_reflect.testTypeNameStructure]"},
+		{name: "generic no package", arg: generic, options: TypeNameOptions{Format: TypeNameNoPackage},
+			want: "testTypeNameGeneric[*testTypeNameStructure]"},
+		{name: "go source alias", arg: generic,
+			options: TypeNameOptions{Format: TypeNameGoSource, ImportAliases: map[string]string{"bvmgo-reflect": "br"}},
+			want:    "br.testTypeNameGeneric[*br.testTypeNameStructure]"},
+		{name: "go source local", arg: func(...any) (error, bool) { return nil, false },
+			options: TypeNameOptions{Format: TypeNameGoSource},
+			want:    "func(...any) (error, bool)"},
+		{name: "nil", arg: nil, options: TypeNameOptions{Format: TypeNameFullPath}, want: "<nil>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TypeNameWith(tt.arg, tt.options); got != tt.want {
+				t.Errorf("TypeNameWith() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeNameOfWith(t *testing.T) {
+	options := TypeNameOptions{Format: TypeNameGoSource, LocalPackage: "bvmgo-reflect"}
+	if got := TypeNameOfWith[testTypeNameInterface](options); got != "testTypeNameInterface" {
+		t.Errorf("TypeNameOfWith() = %v, want %v", got, "testTypeNameInterface")
+	}
+}
+
+func TestAssumedPackageName(t *testing.T) {
+	tests := []struct {
+		pkgPath string
+		want    string
+	}{
+		{pkgPath: "encoding/json", want: "json"},
+		{pkgPath: "bvmgo-reflect", want: "bvmgo"},
+		{pkgPath: "github.com/user/go-yaml", want: "yaml"},
+		{pkgPath: "gopkg.in/yaml.v3", want: "yaml"},
+		{pkgPath: "example.com/module/v2", want: "module"},
+		{pkgPath: "example.com/9lives", want: "_9lives"},
+		{pkgPath: "example.com/-", want: "_"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pkgPath, func(t *testing.T) {
+			if got := assumedPackageName(tt.pkgPath); got != tt.want {
+				t.Errorf("assumedPackageName(%s) = %v, want %v", tt.pkgPath, got, tt.want)
+			}
+		})
+	}
+}
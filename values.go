@@ -0,0 +1,261 @@
+package bvmgo_reflect
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// NestedKeyStyle defines how nested structure keys are built by EncodeValues.
+type NestedKeyStyle int
+
+const (
+	// NestedKeyDotted builds nested keys with dots ("address.city").
+	NestedKeyDotted NestedKeyStyle = iota
+	// NestedKeyBracketed builds nested keys with brackets ("address[city]").
+	NestedKeyBracketed
+)
+
+// ValuesOptions configures EncodeValuesWith function.
+type ValuesOptions struct {
+	// TagName is the structure tag used to name keys ("url" if empty).
+	TagName string
+	// NestedKeys defines how nested structure keys are built.
+	NestedKeys NestedKeyStyle
+}
+
+// EncodeValues function returns the url.Values (query parameters or form) built from a structure.
+//
+// Keys are read from "url" tag (`url:"name,omitempty"`), or from field name if no tag is defined.
+// Fields tagged `url:"-"` and unexported fields are ignored.
+// Slices and arrays are encoded as repeated keys, nested structures and maps use dotted keys
+// and encoding.TextMarshaler values are encoded with their MarshalText method.
+//
+// EncodeValues function returns an error if:
+//   - source is nil or invalid,
+//   - source is not a structure (or a pointer to a structure),
+//   - a field type is not supported (channel, function...).
+func EncodeValues(source any) (url.Values, error) {
+	return EncodeValuesWith(source, ValuesOptions{})
+}
+
+// EncodeValuesWith function returns the url.Values built from a structure with options.
+//
+// See EncodeValues function.
+func EncodeValuesWith(source any, options ValuesOptions) (url.Values, error) {
+	if len(options.TagName) == 0 {
+		options.TagName = "url"
+	}
+	sourceValue := reflect.ValueOf(source)
+	// Check is not null
+	if !sourceValue.IsValid() || (sourceValue.Kind() == reflect.Ptr && sourceValue.IsNil()) {
+		return nil, fmt.Errorf("a not nil pointer is required to encode values")
+	}
+	// If pointer, get pointed element
+	if sourceValue.Kind() == reflect.Ptr {
+		sourceValue = sourceValue.Elem()
+	}
+	// Check is a structure
+	if sourceValue.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported type [%s], a structure is required to encode values",
+			typeName(sourceValue.Type()))
+	}
+	values := url.Values{}
+	encoder := valuesEncoder{options: options, values: values}
+	if err := encoder.encodeStruct("", sourceValue); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// valuesEncoder accumulates url.Values while walking a structure.
+type valuesEncoder struct {
+	options ValuesOptions
+	values  url.Values
+}
+
+// textMarshalerType is the reflect.Type of encoding.TextMarshaler interface.
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// nestedKey function returns the key of a child element according to nested key style.
+func (encoder *valuesEncoder) nestedKey(parentKey string, childKey string) string {
+	if len(parentKey) == 0 {
+		return childKey
+	}
+	if encoder.options.NestedKeys == NestedKeyBracketed {
+		return parentKey + "[" + childKey + "]"
+	}
+	return parentKey + "." + childKey
+}
+
+// encodeStruct function adds all structure fields to values.
+func (encoder *valuesEncoder) encodeStruct(parentKey string, structValue reflect.Value) error {
+	structType := structValue.Type()
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		tag := field.Tag.Get(encoder.options.TagName)
+		if tag == "-" {
+			continue
+		}
+		name, tagOptions, _ := strings.Cut(tag, ",")
+		omitEmpty := hasTagOption(tagOptions, "omitempty")
+		fieldValue := structValue.Field(index)
+		// Embedded structure without name: fields are promoted
+		if field.Anonymous && len(name) == 0 {
+			embeddedValue := fieldValue
+			if embeddedValue.Kind() == reflect.Ptr {
+				if embeddedValue.IsNil() {
+					continue
+				}
+				embeddedValue = embeddedValue.Elem()
+			}
+			if embeddedValue.Kind() == reflect.Struct && !embeddedValue.Type().Implements(textMarshalerType) {
+				if err := encoder.encodeStruct(parentKey, embeddedValue); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+		if omitEmpty && isEmptyValue(fieldValue) {
+			continue
+		}
+		if err := encoder.encodeValue(encoder.nestedKey(parentKey, name), fieldValue); err != nil {
+			return fmt.Errorf("[%s.%s] field cannot be encoded: %w", typeName(structType), field.Name, err)
+		}
+	}
+	return nil
+}
+
+// encodeValue function adds value to values with key.
+func (encoder *valuesEncoder) encodeValue(key string, value reflect.Value) error {
+	// Dereference pointers and interfaces, nil values are ignored
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		if value.Kind() == reflect.Ptr && value.Type().Implements(textMarshalerType) {
+			break
+		}
+		value = value.Elem()
+	}
+	if text, ok, err := marshalText(value); ok || err != nil {
+		if err != nil {
+			return err
+		}
+		encoder.values.Add(key, text)
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		return encoder.encodeStruct(key, value)
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map key type [%s], a string is required", typeName(value.Type().Key()))
+		}
+		iterator := value.MapRange()
+		for iterator.Next() {
+			if err := encoder.encodeValue(encoder.nestedKey(key, iterator.Key().String()), iterator.Value()); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		for index := 0; index < value.Len(); index++ {
+			element := value.Index(index)
+			for element.Kind() == reflect.Ptr || element.Kind() == reflect.Interface {
+				if element.IsNil() {
+					break
+				}
+				element = element.Elem()
+			}
+			// Slice of structures: keys contain element index
+			if element.Kind() == reflect.Struct && !element.Type().Implements(textMarshalerType) {
+				if err := encoder.encodeStruct(encoder.nestedKey(key, strconv.Itoa(index)), element); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := encoder.encodeValue(key, element); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		text, err := formatScalar(value)
+		if err != nil {
+			return err
+		}
+		encoder.values.Add(key, text)
+		return nil
+	}
+}
+
+// marshalText function returns the text of a value implementing encoding.TextMarshaler.
+//
+// ok is false if neither value nor its pointer implements encoding.TextMarshaler.
+func marshalText(value reflect.Value) (text string, ok bool, err error) {
+	var marshaler encoding.TextMarshaler
+	if value.Type().Implements(textMarshalerType) {
+		marshaler = value.Interface().(encoding.TextMarshaler)
+	} else if value.CanAddr() && reflect.PointerTo(value.Type()).Implements(textMarshalerType) {
+		marshaler = value.Addr().Interface().(encoding.TextMarshaler)
+	} else {
+		return
+	}
+	ok = true
+	bytes, err := marshaler.MarshalText()
+	text = string(bytes)
+	return
+}
+
+// formatScalar function returns the string representation of a scalar value.
+func formatScalar(value reflect.Value) (string, error) {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64), nil
+	case reflect.Complex64:
+		return strconv.FormatComplex(value.Complex(), 'g', -1, 64), nil
+	case reflect.Complex128:
+		return strconv.FormatComplex(value.Complex(), 'g', -1, 128), nil
+	default:
+		return "", fmt.Errorf("unsupported type [%s]", typeName(value.Type()))
+	}
+}
+
+// isEmptyValue function returns true if value is empty ("omitempty" tag option):
+// encoding/json rules (see ZeroOptions.OmitEmpty), except that zero structures are empty.
+func isEmptyValue(value reflect.Value) bool {
+	if value.Kind() == reflect.Struct {
+		return isZeroValue(value, ZeroOptions{}, make(map[visitedPointer]bool))
+	}
+	return isZeroValue(value, ZeroOptions{OmitEmpty: true}, nil)
+}
+
+// hasTagOption function returns true if comma separated tag options contain option.
+func hasTagOption(tagOptions string, option string) bool {
+	for _, tagOption := range strings.Split(tagOptions, ",") {
+		if strings.TrimSpace(tagOption) == option {
+			return true
+		}
+	}
+	return false
+}
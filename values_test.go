@@ -0,0 +1,166 @@
+package bvmgo_reflect
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testValuesAddress struct {
+	City    string `url:"city"`
+	ZipCode string `url:"zip,omitempty"`
+}
+
+type testValuesEmbedded struct {
+	Page int `url:"page"`
+}
+
+type testValuesStruct struct {
+	testValuesEmbedded
+	Query    string              `url:"q"`
+	Limit    int                 `url:"limit,omitempty"`
+	Ratio    float64             `url:"ratio,omitempty"`
+	Enabled  bool                `url:"enabled"`
+	Tags     []string            `url:"tag"`
+	Address  testValuesAddress   `url:"address"`
+	Optional *string             `url:"optional"`
+	Since    time.Time           `url:"since"`
+	Items    []testValuesAddress `url:"items,omitempty"`
+	Ignored  string              `url:"-"`
+	NoTag    string
+	private  string
+}
+
+func TestEncodeValues_struct(t *testing.T) {
+	since := time.Date(2024, 5, 17, 10, 30, 0, 0, time.UTC)
+	source := testValuesStruct{
+		testValuesEmbedded: testValuesEmbedded{Page: 2},
+		Query:              "golang",
+		Ratio:              0.5,
+		Enabled:            true,
+		Tags:               []string{"a", "b"},
+		Address:            testValuesAddress{City: "Paris"},
+		Since:              since,
+		Ignored:            "ignored",
+		NoTag:              "value",
+		private:            "private",
+	}
+	values, err := EncodeValues(&source)
+	if err != nil {
+		t.Errorf("EncodeValues(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	expectedValues := url.Values{
+		"page":         {"2"},
+		"q":            {"golang"},
+		"ratio":        {"0.5"},
+		"enabled":      {"true"},
+		"tag":          {"a", "b"},
+		"address.city": {"Paris"},
+		"since":        {"2024-05-17T10:30:00Z"},
+		"NoTag":        {"value"},
+	}
+	if !reflect.DeepEqual(values, expectedValues) {
+		t.Errorf("EncodeValues(...) = [%v], want [%v]", values, expectedValues)
+	}
+}
+
+func TestEncodeValuesWith_bracketed(t *testing.T) {
+	optional := "set"
+	source := testValuesStruct{
+		Address:  testValuesAddress{City: "Lyon", ZipCode: "69000"},
+		Optional: &optional,
+		Items:    []testValuesAddress{{City: "Nantes"}},
+	}
+	values, err := EncodeValuesWith(source, ValuesOptions{NestedKeys: NestedKeyBracketed})
+	if err != nil {
+		t.Errorf("EncodeValuesWith(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	tests := map[string]string{
+		"address[city]":  "Lyon",
+		"address[zip]":   "69000",
+		"optional":       "set",
+		"items[0][city]": "Nantes",
+		"q":              "",
+	}
+	for key, expectedValue := range tests {
+		if !values.Has(key) || values.Get(key) != expectedValue {
+			t.Errorf("values.Get(%s) = [%v], want [%v]", key, values.Get(key), expectedValue)
+		}
+	}
+	if values.Has("limit") {
+		t.Errorf("values.Has(limit) = true, want false (omitempty)")
+	}
+}
+
+func TestEncodeValues_map(t *testing.T) {
+	source := struct {
+		Filters map[string]int `url:"filter"`
+	}{Filters: map[string]int{"min": 1}}
+	values, err := EncodeValues(source)
+	if err != nil {
+		t.Errorf("EncodeValues(...) returns \"%v\" error, want no error", err)
+		return
+	}
+	if values.Get("filter.min") != "1" {
+		t.Errorf("values.Get(filter.min) = [%v], want [%v]", values.Get("filter.min"), "1")
+	}
+}
+
+func TestEncodeValues_notStruct(t *testing.T) {
+	_, err := EncodeValues("value")
+	if err == nil {
+		t.Errorf("EncodeValues(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "a structure is required") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "a structure is required")
+	}
+}
+
+func TestEncodeValues_nil(t *testing.T) {
+	var source *testValuesStruct = nil
+	_, err := EncodeValues(source)
+	if err == nil {
+		t.Errorf("EncodeValues(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "a not nil pointer is required") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "a not nil pointer is required")
+	}
+}
+
+func TestEncodeValues_unsupportedField(t *testing.T) {
+	source := struct {
+		Callback func()
+	}{Callback: func() {}}
+	_, err := EncodeValues(source)
+	if err == nil {
+		t.Errorf("EncodeValues(...) returns nil (no error), want an error")
+		return
+	}
+	if !strings.Contains(err.Error(), "field cannot be encoded") {
+		t.Errorf("err.Error() = [%v], want contain [%v]", err.Error(), "field cannot be encoded")
+	}
+}
+
+func TestEncodeValues_omitEmptyArray(t *testing.T) {
+	source := struct {
+		Zeros [2]int `url:"zeros,omitempty"`
+		Empty [0]int `url:"empty,omitempty"`
+	}{}
+	values, err := EncodeValues(source)
+	if err != nil {
+		t.Errorf("EncodeValues() error = %v, want no Error", err)
+		return
+	}
+	if got := values["zeros"]; len(got) != 2 || got[0] != "0" || got[1] != "0" {
+		t.Errorf("EncodeValues() zeros = %v, want [0 0]", got)
+	}
+	if _, found := values["empty"]; found {
+		t.Errorf("EncodeValues() = %v, want no empty key", values)
+	}
+}
@@ -0,0 +1,137 @@
+package bvmgo_reflect
+
+import (
+	"reflect"
+)
+
+// ZeroOptions configures IsZeroWith function.
+type ZeroOptions struct {
+	// EmptyIsZero considers empty but not nil slices and maps as zero.
+	EmptyIsZero bool
+	// ExportedOnly considers a structure as zero if all its exported fields are zero (unexported fields are ignored).
+	ExportedOnly bool
+	// UseIsZeroMethods checks values having an "IsZero() bool" method (like time.Time) with this method.
+	UseIsZeroMethods bool
+	// DereferencePointers considers a not nil pointer (or interface) to a zero value as zero.
+	DereferencePointers bool
+	// OmitEmpty applies the "omitempty" rules of encoding/json (other options are ignored): false, 0,
+	// nil pointers and interfaces, and empty arrays, slices, maps and strings are zero; structures are never zero.
+	OmitEmpty bool
+}
+
+// isZeroerType is the reflect.Type of "IsZero() bool" method interface.
+var isZeroerType = reflect.TypeOf((*interface{ IsZero() bool })(nil)).Elem()
+
+// IsZero function returns true if v is deeply zero (see reflect.Value.IsZero).
+//
+// Nil pointers, nil slices, nil maps, nil interfaces and nil v are zero,
+// arrays and structures are zero if all their elements (fields) are zero.
+func IsZero(v any) bool {
+	return IsZeroWith(v, ZeroOptions{})
+}
+
+// IsZeroWith function returns true if v is deeply zero according to options.
+//
+// See IsZero function.
+func IsZeroWith(v any, options ZeroOptions) bool {
+	return isZeroValue(reflect.ValueOf(v), options, make(map[visitedPointer]bool))
+}
+
+// visitedPointer is a dereferenced pointer (cycles detection).
+type visitedPointer struct {
+	pointer     uintptr
+	pointerType reflect.Type
+}
+
+// isZeroValue function returns true if value is deeply zero according to options.
+func isZeroValue(value reflect.Value, options ZeroOptions, visited map[visitedPointer]bool) bool {
+	if !value.IsValid() {
+		return true
+	}
+	if options.OmitEmpty {
+		return isOmitEmptyValue(value)
+	}
+	if options.UseIsZeroMethods {
+		if zero, ok := callIsZero(value); ok {
+			return zero
+		}
+	}
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return true
+		}
+		if !options.DereferencePointers {
+			return false
+		}
+		if value.Kind() == reflect.Ptr {
+			key := visitedPointer{pointer: value.Pointer(), pointerType: value.Type()}
+			// A cycle adds no not zero value
+			if visited[key] {
+				return true
+			}
+			visited[key] = true
+		}
+		return isZeroValue(value.Elem(), options, visited)
+	case reflect.Slice, reflect.Map:
+		return value.IsNil() || (options.EmptyIsZero && value.Len() == 0)
+	case reflect.Array:
+		for index := 0; index < value.Len(); index++ {
+			if !isZeroValue(value.Index(index), options, visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		structType := value.Type()
+		for index := 0; index < structType.NumField(); index++ {
+			if options.ExportedOnly && !structType.Field(index).IsExported() {
+				continue
+			}
+			if !isZeroValue(value.Field(index), options, visited) {
+				return false
+			}
+		}
+		return true
+	default:
+		return value.IsZero()
+	}
+}
+
+// isOmitEmptyValue function returns true if value is empty according to encoding/json "omitempty" rules.
+func isOmitEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.String:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	case reflect.Float32, reflect.Float64:
+		return value.Float() == 0
+	case reflect.Struct:
+		return false
+	default:
+		return value.IsZero()
+	}
+}
+
+// callIsZero function returns the result of the "IsZero() bool" method of value.
+//
+// ok is false if value has no such method (or is a nil pointer, or cannot be used).
+func callIsZero(value reflect.Value) (zero bool, ok bool) {
+	if !value.CanInterface() || ((value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil()) {
+		return
+	}
+	if value.Type().Implements(isZeroerType) {
+		return value.Interface().(interface{ IsZero() bool }).IsZero(), true
+	}
+	if value.Kind() != reflect.Interface && reflect.PointerTo(value.Type()).Implements(isZeroerType) {
+		if !value.CanAddr() {
+			// Copy value to call a pointer receiver method
+			pointer := reflect.New(value.Type())
+			pointer.Elem().Set(value)
+			value = pointer.Elem()
+		}
+		return value.Addr().Interface().(interface{ IsZero() bool }).IsZero(), true
+	}
+	return
+}
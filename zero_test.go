@@ -0,0 +1,81 @@
+package bvmgo_reflect
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+type testZeroStruct struct {
+	Name     string
+	Tags     []string
+	When     time.Time
+	Pointer  *int
+	internal int
+}
+
+type testZeroNode struct {
+	Next *testZeroNode
+}
+
+type testZeroPointerMethod struct {
+	Value int
+}
+
+func (value *testZeroPointerMethod) IsZero() bool {
+	return value.Value <= 0
+}
+
+func TestIsZeroWith(t *testing.T) {
+	zero := 0
+	local := time.Time{}.In(time.FixedZone("UTC+1", 3600))
+	cycle := &testZeroNode{}
+	cycle.Next = cycle
+	tests := []struct {
+		name    string
+		value   any
+		options ZeroOptions
+		want    bool
+	}{
+		{"Nil", nil, ZeroOptions{}, true},
+		{"Zero structure", testZeroStruct{}, ZeroOptions{}, true},
+		{"Not zero structure", testZeroStruct{Name: "a"}, ZeroOptions{}, false},
+		{"Empty slice", testZeroStruct{Tags: []string{}}, ZeroOptions{}, false},
+		{"Empty slice is zero", testZeroStruct{Tags: []string{}}, ZeroOptions{EmptyIsZero: true}, true},
+		{"Empty map is zero", map[string]int{}, ZeroOptions{EmptyIsZero: true}, true},
+		{"Not empty slice", []string{""}, ZeroOptions{EmptyIsZero: true}, false},
+		{"Unexported field", testZeroStruct{internal: 1}, ZeroOptions{}, false},
+		{"Unexported field ignored", testZeroStruct{internal: 1}, ZeroOptions{ExportedOnly: true}, true},
+		{"Time with location", testZeroStruct{When: local}, ZeroOptions{}, false},
+		{"Time with location IsZero method", testZeroStruct{When: local}, ZeroOptions{UseIsZeroMethods: true}, true},
+		{"Pointer receiver IsZero method", testZeroPointerMethod{Value: -1}, ZeroOptions{UseIsZeroMethods: true}, true},
+		{"Pointer to zero", testZeroStruct{Pointer: &zero}, ZeroOptions{}, false},
+		{"Pointer to zero dereferenced", testZeroStruct{Pointer: &zero}, ZeroOptions{DereferencePointers: true}, true},
+		{"Interface to zero", [1]any{0}, ZeroOptions{}, false},
+		{"Interface to zero dereferenced", [1]any{0}, ZeroOptions{DereferencePointers: true}, true},
+		{"Cycle", cycle, ZeroOptions{DereferencePointers: true}, true},
+		{"Array", [2]int{0, 1}, ZeroOptions{}, false},
+		{"Omit empty array", [0]int{}, ZeroOptions{OmitEmpty: true}, true},
+		{"Omit empty not empty array", [1]int{}, ZeroOptions{OmitEmpty: true}, false},
+		{"Omit empty structure", testZeroStruct{}, ZeroOptions{OmitEmpty: true}, false},
+		{"Omit empty negative zero", math.Copysign(0, -1), ZeroOptions{OmitEmpty: true}, true},
+		{"Omit empty slice", []string{}, ZeroOptions{OmitEmpty: true}, true},
+		{"Omit empty pointer to zero", &zero, ZeroOptions{OmitEmpty: true, DereferencePointers: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsZeroWith(tt.value, tt.options); got != tt.want {
+				t.Errorf("IsZeroWith(%+v, %+v) = %v, want %v", tt.value, tt.options, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	if !IsZero([3]string{}) {
+		t.Errorf("IsZero([3]string{}) = false, want true")
+	}
+	if IsZero(&testZeroStruct{}) {
+		t.Errorf("IsZero(&testZeroStruct{}) = true, want false")
+	}
+}